@@ -0,0 +1,244 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package encode
+
+import (
+	"github.com/michael-gallo/simpleical/model"
+)
+
+// calendar writes a VCALENDAR and its components.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.4
+func (e *encoder) calendar(calendar *model.Calendar) {
+	e.require("PRODID", calendar.ProdID)
+	e.require("VERSION", calendar.Version)
+	e.begin(string(model.SectionTokenVCalendar))
+	e.text("PRODID", calendar.ProdID)
+	e.raw("VERSION", calendar.Version)
+	e.raw("CALSCALE", calendar.CalScale)
+	e.raw("METHOD", calendar.Method)
+	e.extraProperties(calendar.XProp, calendar.IANAProp)
+	for i := range calendar.TimeZones {
+		e.timeZone(&calendar.TimeZones[i])
+	}
+	for i := range calendar.Events {
+		e.event(&calendar.Events[i])
+	}
+	for i := range calendar.Todos {
+		e.todo(&calendar.Todos[i])
+	}
+	for i := range calendar.Journals {
+		e.journal(&calendar.Journals[i])
+	}
+	for i := range calendar.FreeBusys {
+		e.freeBusy(&calendar.FreeBusys[i])
+	}
+	e.components(calendar.OtherComponents)
+	e.end(string(model.SectionTokenVCalendar))
+}
+
+// event writes a VEVENT and its alarms.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.1
+func (e *encoder) event(event *model.Event) {
+	e.begin(string(model.SectionTokenVEvent))
+	e.utcTime(string(model.EventTokenDTStamp), event.DTStamp)
+	e.text(string(model.EventTokenUID), event.UID)
+	e.dateTime(string(model.EventTokenDtstart), event.Start)
+	e.raw(string(model.EventTokenClass), string(event.Class))
+	e.utcTime(string(model.EventTokenCreated), event.Created)
+	e.textProperty(string(model.EventTokenDescription), event.Description)
+	e.geo(event.Geo)
+	e.utcTime(string(model.EventTokenLastModified), event.LastModified)
+	e.textProperty(string(model.EventTokenLocation), event.Location)
+	e.organizer(event.Organizer)
+	e.integer(string(model.EventTokenPriority), event.Priority)
+	e.integer(string(model.EventTokenSequence), event.Sequence)
+	e.raw(string(model.EventTokenStatus), string(event.Status))
+	e.textProperty(string(model.EventTokenSummary), event.Summary)
+	e.raw(string(model.EventTokenTransp), string(event.Transp))
+	e.raw(string(model.EventTokenURL), event.URL)
+	e.dateTime(string(model.EventTokenRecurrenceID), event.RecurrenceID)
+	e.recurrenceRule(event.RRule)
+	e.dateTime(string(model.EventTokenDtend), event.End)
+	e.duration(string(model.EventTokenDuration), event.Duration)
+	e.attachments(event.Attach)
+	e.attendees(event.Attendees)
+	e.textList(string(model.EventTokenCategories), event.Categories)
+	e.textProperties(string(model.EventTokenComment), event.Comment)
+	e.textProperties(string(model.EventTokenContact), event.Contacts)
+	e.dateTimes(string(model.EventTokenExceptionDates), event.ExceptionDates)
+	e.requestStatuses(event.RequestStatus)
+	e.relations(event.Related)
+	e.textProperties(string(model.EventTokenResources), event.Resources)
+	e.dateTimes(string(model.EventTokenRdate), event.Rdate)
+	e.extraProperties(event.XProp, event.IANAProp)
+	e.alarms(event.Alarms)
+	e.components(event.OtherComponents)
+	e.end(string(model.SectionTokenVEvent))
+}
+
+// todo writes a VTODO and its alarms.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.2
+func (e *encoder) todo(todo *model.Todo) {
+	e.begin(string(model.SectionTokenVTodo))
+	e.utcTime(string(model.TodoTokenDTStamp), todo.DTStamp)
+	e.text(string(model.TodoTokenUID), todo.UID)
+	e.raw(string(model.TodoTokenClass), string(todo.Class))
+	e.utcTime(string(model.TodoTokenCompleted), todo.Completed)
+	e.utcTime(string(model.TodoTokenCreated), todo.Created)
+	e.textProperties(string(model.TodoTokenDescription), todo.Description)
+	e.dateTime(string(model.TodoTokenDTStart), todo.DTStart)
+	e.geo(todo.Geo)
+	e.utcTime(string(model.TodoTokenLastModified), todo.LastModified)
+	e.textProperty(string(model.TodoTokenLocation), todo.Location)
+	e.organizer(todo.Organizer)
+	e.integer(string(model.TodoTokenPercentComplete), todo.PercentComplete)
+	e.integer(string(model.TodoTokenPriority), todo.Priority)
+	e.dateTime(string(model.TodoTokenRecurrenceID), todo.RecurrenceID)
+	e.integer(string(model.TodoTokenSequence), todo.Sequence)
+	e.raw(string(model.TodoTokenStatus), string(todo.Status))
+	e.textProperty(string(model.TodoTokenSummary), todo.Summary)
+	e.raw(string(model.TodoTokenTransp), string(todo.Transp))
+	e.raw(string(model.TodoTokenURL), todo.URL)
+	e.dateTime(string(model.TodoTokenDue), todo.Due)
+	e.duration(string(model.TodoTokenDuration), todo.Duration)
+	e.attachments(todo.Attach)
+	e.attendees(todo.Attendees)
+	e.textList(string(model.TodoTokenCategories), todo.Categories)
+	e.textProperties(string(model.TodoTokenComment), todo.Comment)
+	e.textProperties(string(model.TodoTokenContact), todo.Contacts)
+	e.dateTimes(string(model.TodoTokenExceptionDates), todo.ExceptionDates)
+	e.requestStatuses(todo.RequestStatus)
+	e.relations(todo.Related)
+	e.textProperties(string(model.TodoTokenResources), todo.Resources)
+	e.dateTimes(string(model.TodoTokenRdate), todo.Rdate)
+	e.extraProperties(todo.XProp, todo.IANAProp)
+	e.alarms(todo.Alarms)
+	e.components(todo.OtherComponents)
+	e.end(string(model.SectionTokenVTodo))
+}
+
+// journal writes a VJOURNAL.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.3
+func (e *encoder) journal(journal *model.Journal) {
+	e.begin(string(model.SectionTokenVJournal))
+	e.utcTime(string(model.JournalTokenDTStamp), journal.DTStamp)
+	e.text(string(model.JournalTokenUID), journal.UID)
+	e.raw(string(model.JournalTokenClass), string(journal.Class))
+	e.utcTime(string(model.JournalTokenCreated), journal.Created)
+	e.dateTime(string(model.JournalTokenDTStart), journal.DTStart)
+	e.utcTime(string(model.JournalTokenLastModified), journal.LastModified)
+	e.organizer(journal.Organizer)
+	e.dateTime(string(model.JournalTokenRecurrenceID), journal.RecurrenceID)
+	e.integer(string(model.JournalTokenSequence), journal.Sequence)
+	e.raw(string(model.JournalTokenStatus), string(journal.Status))
+	e.textProperty(string(model.JournalTokenSummary), journal.Summary)
+	e.raw(string(model.JournalTokenURL), journal.URL)
+	e.recurrenceRule(journal.RRule)
+	e.attachments(journal.Attach)
+	e.attendees(journal.Attendees)
+	e.textList(string(model.JournalTokenCategories), journal.Categories)
+	e.textProperties(string(model.JournalTokenComment), journal.Comment)
+	e.textProperties(string(model.JournalTokenContact), journal.Contacts)
+	e.textProperties(string(model.JournalTokenDescription), journal.Description)
+	e.dateTimes(string(model.JournalTokenExceptionDates), journal.ExceptionDates)
+	e.relations(journal.Related)
+	e.dateTimes(string(model.JournalTokenRdate), journal.Rdate)
+	e.requestStatuses(journal.RequestStatus)
+	e.extraProperties(journal.XProp, journal.IANAProp)
+	e.alarms(journal.Alarms)
+	e.components(journal.OtherComponents)
+	e.end(string(model.SectionTokenVJournal))
+}
+
+// freeBusy writes a VFREEBUSY.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.4
+func (e *encoder) freeBusy(freeBusy *model.FreeBusy) {
+	e.begin(string(model.SectionTokenVFreebusy))
+	e.utcTime(string(model.FreeBusyTokenDTStamp), freeBusy.DTStamp)
+	e.text(string(model.FreeBusyTokenUID), freeBusy.UID)
+	e.textProperty(string(model.FreeBusyTokenContact), freeBusy.Contact)
+	e.utcTime(string(model.FreeBusyTokenDTStart), freeBusy.DTStart)
+	e.utcTime(string(model.FreeBusyTokenDTEnd), freeBusy.DTEnd)
+	e.organizer(freeBusy.Organizer)
+	e.raw(string(model.FreeBusyTokenURL), freeBusy.URL)
+	e.attendees(freeBusy.Attendees)
+	e.textProperties(string(model.FreeBusyTokenComment), freeBusy.Comment)
+	e.freeBusyTimes(freeBusy.FreeBusy)
+	e.requestStatuses(freeBusy.RequestStatus)
+	e.extraProperties(freeBusy.XProp, freeBusy.IANAProp)
+	e.components(freeBusy.OtherComponents)
+	e.end(string(model.SectionTokenVFreebusy))
+}
+
+// timeZone writes a VTIMEZONE and its STANDARD and DAYLIGHT sub-components.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.5
+func (e *encoder) timeZone(timeZone *model.TimeZone) {
+	e.begin(string(model.SectionTokenVTimezone))
+	e.text(string(model.TimezoneTokenTimeZoneID), timeZone.TimeZoneID)
+	e.utcTime(string(model.TimezoneTokenLastMod), timeZone.LastMod)
+	e.uri(string(model.TimezoneTokenTimeZoneURL), timeZone.TimeZoneURL)
+	e.extraProperties(timeZone.XProp, timeZone.IANAProp)
+	for i := range timeZone.Standard {
+		e.observance(string(model.SectionTokenVStandard), &timeZone.Standard[i])
+	}
+	for i := range timeZone.Daylight {
+		e.observance(string(model.SectionTokenVDaylight), &timeZone.Daylight[i])
+	}
+	e.components(timeZone.OtherComponents)
+	e.end(string(model.SectionTokenVTimezone))
+}
+
+// observance writes a STANDARD or DAYLIGHT sub-component of a VTIMEZONE.
+// Its DTSTART and RDATE values are local times, so they are written as floating times.
+func (e *encoder) observance(name string, observance *model.TimeZoneProperty) {
+	e.begin(name)
+	e.floatingTime(string(model.TimezoneTokenDTStart), observance.DTStart)
+	e.utcOffset(string(model.TimezoneTokenTimeZoneOffsetTo), observance.TimeZoneOffsetTo)
+	e.utcOffset(string(model.TimezoneTokenTimeZoneOffsetFrom), observance.TimeZoneOffsetFrom)
+	e.recurrenceRule(observance.RRule)
+	e.textProperties(string(model.TimezoneTokenComment), observance.Comment)
+	for _, rdate := range observance.Rdate {
+		e.floatingTime(string(model.TimezoneTokenRdate), rdate)
+	}
+	for _, name := range observance.TimeZoneName {
+		e.text(string(model.TimezoneTokenTimeZoneName), name)
+	}
+	e.extraProperties(observance.XProp, observance.IANAProp)
+	e.components(observance.OtherComponents)
+	e.end(name)
+}
+
+// alarms writes a VALARM per alarm.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.6
+func (e *encoder) alarms(alarms []model.Alarm) {
+	for i := range alarms {
+		alarm := &alarms[i]
+		e.begin(string(model.SectionTokenVAlarm))
+		e.raw(string(model.AlarmTokenAction), string(alarm.Action))
+		e.trigger(alarm.Trigger)
+		e.duration(string(model.AlarmTokenDuration), alarm.Duration)
+		e.integer(string(model.AlarmTokenRepeat), alarm.Repeat)
+		e.textProperties(string(model.AlarmTokenDescription), alarm.Description)
+		e.textProperty(string(model.AlarmTokenSummary), alarm.Summary)
+		e.attendees(alarm.Attendees)
+		e.attachments(alarm.Attach)
+		e.extraProperties(alarm.XProp, alarm.IANAProp)
+		e.components(alarm.OtherComponents)
+		e.end(string(model.SectionTokenVAlarm))
+	}
+}
+
+// components writes components the model has no dedicated type for, with their properties as they were read.
+func (e *encoder) components(components []model.Component) {
+	for i := range components {
+		component := &components[i]
+		e.begin(component.Name)
+		for _, property := range component.Properties {
+			e.property(property)
+		}
+		e.components(component.Components)
+		e.end(component.Name)
+	}
+}
@@ -0,0 +1,10 @@
+// Package encode writes model.Calendar values as iCalendar (RFC 5545) data, the inverse of the parse package.
+//
+// Lines end in CRLF and are folded at 75 octets without splitting a UTF-8 sequence.
+// TEXT values are escaped and parameter values are quoted where needed. A line break in a field can
+// never start a new content line: it is written as \n in a TEXT value and as ^n in a parameter value,
+// and any other value holding a line break is rejected with ErrLineBreakInValue.
+//
+// TEXT fields are expected to hold decoded text, as the parse package returns them by default.
+// Calendars parsed with parse.WithRawText keep their escapes, which would be escaped a second time.
+package encode
@@ -0,0 +1,60 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package encode
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/michael-gallo/simpleical/model"
+)
+
+var (
+	// ErrNilCalendar is returned by Encode for a nil calendar.
+	ErrNilCalendar = errors.New("calendar is nil")
+	// ErrInvalidName is returned for a property, parameter or component name that is empty or holds
+	// characters other than letters, digits and dashes.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.1
+	ErrInvalidName = errors.New("invalid name")
+	// ErrLineBreakInValue is returned for a value other than TEXT, such as a URI or an X- property,
+	// that holds a carriage return or a line feed, which would otherwise end the content line.
+	ErrLineBreakInValue = errors.New("line break in value")
+	// ErrMissingValue is returned for a property that must be written but has no value, such as the
+	// VERSION or PRODID of the calendar, or the calendar address of an ORGANIZER or ATTENDEE.
+	ErrMissingValue = errors.New("missing value")
+)
+
+// Encode writes cal to w as an iCalendar object.
+// Properties are written in the order RFC 5545 lists them, followed by the X- and IANA properties
+// sorted by name. The VTIMEZONE components come before the events, to-dos, journals and free/busy
+// components that refer to them, and VALARM components follow the properties of their event or to-do.
+// Unset fields are left out. A calendar without VERSION or PRODID, or an organizer or attendee without
+// a calendar address, is rejected with ErrMissingValue. The calendar is not validated otherwise, so one
+// that is missing another required property such as UID is written without it.
+// Output is buffered, but if an error is returned part of the calendar may already have been written to w.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.4
+func Encode(w io.Writer, cal *model.Calendar) error {
+	if cal == nil {
+		return ErrNilCalendar
+	}
+	e := &encoder{w: bufio.NewWriter(w)}
+	e.calendar(cal)
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// encoder writes content lines to a buffered writer.
+// The first error is kept in err, and once it is set nothing else is written.
+type encoder struct {
+	w *bufio.Writer
+	// name is the name of the content line being built.
+	name string
+	// line holds the content line being built, before it is folded.
+	line []byte
+	err  error
+}
@@ -0,0 +1,44 @@
+package encode_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/michael-gallo/simpleical/encode"
+	"github.com/michael-gallo/simpleical/model"
+)
+
+func ExampleEncode() {
+	calendar := &model.Calendar{
+		Version: "2.0",
+		ProdID:  "-//Example//Example Calendar//EN",
+		Events: []model.Event{
+			{
+				UID:      "meeting-1@example.com",
+				DTStamp:  time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+				Start:    model.DateTime{Time: time.Date(2025, time.January, 6, 15, 0, 0, 0, time.UTC)},
+				Duration: time.Hour,
+				Summary:  model.Text{Value: "Planning, budget; and review"},
+			},
+		},
+	}
+	var output strings.Builder
+	if err := encode.Encode(&output, calendar); err != nil {
+		panic(err)
+	}
+	// Lines end in CRLF, which is printed as a plain line break here.
+	fmt.Print(strings.ReplaceAll(output.String(), "\r\n", "\n"))
+	// Output:
+	// BEGIN:VCALENDAR
+	// PRODID:-//Example//Example Calendar//EN
+	// VERSION:2.0
+	// BEGIN:VEVENT
+	// DTSTAMP:20250101T120000Z
+	// UID:meeting-1@example.com
+	// DTSTART:20250106T150000Z
+	// SUMMARY:Planning\, budget\; and review
+	// DURATION:PT1H
+	// END:VEVENT
+	// END:VCALENDAR
+}
@@ -0,0 +1,226 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package encode
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/michael-gallo/simpleical/model"
+)
+
+// maxLineOctets is the length a content line is folded at, not counting the CRLF.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.1
+const maxLineOctets = 75
+
+// begin writes the BEGIN line of a component.
+func (e *encoder) begin(name string) {
+	e.delimiter("BEGIN", name)
+}
+
+// end writes the END line of a component.
+func (e *encoder) end(name string) {
+	e.delimiter("END", name)
+}
+
+// delimiter writes a BEGIN or END line, whose value is a component name.
+func (e *encoder) delimiter(kind, name string) {
+	if e.err == nil && !isValidName(name) {
+		e.err = fmt.Errorf("%w: component %q", ErrInvalidName, name)
+		return
+	}
+	e.startLine(kind)
+	e.rawValue(name)
+}
+
+// require fails with ErrMissingValue if the value of a property that must be written is empty.
+func (e *encoder) require(name, value string) {
+	if e.err == nil && value == "" {
+		e.err = fmt.Errorf("%w: %s", ErrMissingValue, name)
+	}
+}
+
+// startLine starts a content line with the property name.
+// Parameters are added with param, uriParam and otherParams, and the line is written by rawValue or textValue.
+func (e *encoder) startLine(name string) {
+	if e.err != nil {
+		return
+	}
+	if !isValidName(name) {
+		e.err = fmt.Errorf("%w: property %q", ErrInvalidName, name)
+		return
+	}
+	e.name = name
+	e.line = append(e.line[:0], name...)
+}
+
+// param adds a parameter to the content line, unless all of its values are empty.
+func (e *encoder) param(name string, values ...string) {
+	if e.err != nil || !slices.ContainsFunc(values, func(value string) bool { return value != "" }) {
+		return
+	}
+	if !isValidName(name) {
+		e.err = fmt.Errorf("%w: parameter %q of %s", ErrInvalidName, name, e.name)
+		return
+	}
+	e.line = append(e.line, ';')
+	e.line = append(e.line, name...)
+	e.line = append(e.line, '=')
+	for i, value := range values {
+		if i > 0 {
+			e.line = append(e.line, ',')
+		}
+		e.line = appendParamValue(e.line, value, false)
+	}
+}
+
+// uriParam adds a parameter holding URIs, such as DIR or MEMBER, to the content line, unless all of them are nil.
+// RFC 5545 requires these values to be quoted.
+func (e *encoder) uriParam(name string, uris ...*url.URL) {
+	if e.err != nil || !slices.ContainsFunc(uris, func(uri *url.URL) bool { return uri != nil }) {
+		return
+	}
+	e.line = append(e.line, ';')
+	e.line = append(e.line, name...)
+	e.line = append(e.line, '=')
+	first := true
+	for _, uri := range uris {
+		if uri == nil {
+			continue
+		}
+		if !first {
+			e.line = append(e.line, ',')
+		}
+		first = false
+		e.line = appendParamValue(e.line, uri.String(), true)
+	}
+}
+
+// otherParams adds parameters the model has no dedicated field for, sorted by name.
+func (e *encoder) otherParams(params model.Parameters) {
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		e.param(name, params[name]...)
+	}
+}
+
+// rawValue ends the content line with a value that is written as it is, and writes the line.
+// The value must not hold a line break.
+func (e *encoder) rawValue(value string) {
+	if e.err != nil {
+		return
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		e.err = fmt.Errorf("%w: %s", ErrLineBreakInValue, e.name)
+		return
+	}
+	e.line = append(e.line, ':')
+	e.line = append(e.line, value...)
+	e.writeLine()
+}
+
+// textValue ends the content line with a TEXT value, escaping it, and writes the line.
+func (e *encoder) textValue(value string) {
+	if e.err != nil {
+		return
+	}
+	e.line = append(e.line, ':')
+	e.line = appendText(e.line, value)
+	e.writeLine()
+}
+
+// writeLine writes the content line, folded so no line is longer than 75 octets.
+// A line is never folded inside a UTF-8 sequence, so it may be folded a few octets early.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.1
+func (e *encoder) writeLine() {
+	line := e.line
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		e.w.Write(line[:cut])
+		e.w.WriteString("\r\n ")
+		line = line[cut:]
+		// The space that starts a continuation line counts towards its length.
+		limit = maxLineOctets - 1
+	}
+	e.w.Write(line)
+	e.w.WriteString("\r\n")
+}
+
+// appendText appends a TEXT value with backslashes, semicolons, commas and line breaks escaped.
+// A CRLF, a lone CR and a lone LF are all written as \n.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.11
+func appendText(b []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		switch character := value[i]; character {
+		case '\\', ';', ',':
+			b = append(b, '\\', character)
+		case '\r':
+			if i+1 < len(value) && value[i+1] == '\n' {
+				i++
+			}
+			b = append(b, '\\', 'n')
+		case '\n':
+			b = append(b, '\\', 'n')
+		default:
+			b = append(b, character)
+		}
+	}
+	return b
+}
+
+// appendParamValue appends a parameter value, encoding carets, double quotes and line breaks with
+// the RFC 6868 caret escapes, and quoting it if it holds a colon, semicolon or comma, or if quote is set.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.2
+// https://datatracker.ietf.org/doc/html/rfc6868
+func appendParamValue(b []byte, value string, quote bool) []byte {
+	quote = quote || strings.ContainsAny(value, ":;,")
+	if quote {
+		b = append(b, '"')
+	}
+	for i := 0; i < len(value); i++ {
+		switch character := value[i]; character {
+		case '^':
+			b = append(b, '^', '^')
+		case '"':
+			b = append(b, '^', '\'')
+		case '\r':
+			if i+1 < len(value) && value[i+1] == '\n' {
+				i++
+			}
+			b = append(b, '^', 'n')
+		case '\n':
+			b = append(b, '^', 'n')
+		default:
+			b = append(b, character)
+		}
+	}
+	if quote {
+		b = append(b, '"')
+	}
+	return b
+}
+
+// isValidName reports whether name can be written as a property, parameter or component name:
+// letters, digits and dashes only.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.1
+func isValidName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		character := name[i]
+		if !('A' <= character && character <= 'Z' || 'a' <= character && character <= 'z' || '0' <= character && character <= '9' || character == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package encode
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteLine(t *testing.T) {
+	testCases := []struct {
+		name string
+		line string
+		want string
+	}{
+		{
+			name: "short line",
+			line: "SUMMARY:Hello",
+			want: "SUMMARY:Hello\r\n",
+		},
+		{
+			name: "exactly 75 octets",
+			line: strings.Repeat("a", 75),
+			want: strings.Repeat("a", 75) + "\r\n",
+		},
+		{
+			name: "76 octets",
+			line: strings.Repeat("a", 76),
+			want: strings.Repeat("a", 75) + "\r\n a\r\n",
+		},
+		{
+			name: "continuation lines hold 74 octets",
+			line: strings.Repeat("a", 75+74+1),
+			want: strings.Repeat("a", 75) + "\r\n " + strings.Repeat("a", 74) + "\r\n a\r\n",
+		},
+		{
+			name: "multi-byte character across the fold",
+			line: strings.Repeat("a", 74) + "é" + "b",
+			want: strings.Repeat("a", 74) + "\r\n éb\r\n",
+		},
+		{
+			name: "four byte character across the fold",
+			line: strings.Repeat("a", 73) + "😀",
+			want: strings.Repeat("a", 73) + "\r\n 😀\r\n",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var output bytes.Buffer
+			e := &encoder{w: bufio.NewWriter(&output), line: []byte(testCase.line)}
+			e.writeLine()
+			assert.NoError(t, e.w.Flush())
+			assert.Equal(t, testCase.want, output.String())
+			for line := range strings.SplitSeq(strings.TrimSuffix(output.String(), "\r\n"), "\r\n") {
+				assert.LessOrEqual(t, len(line), maxLineOctets)
+			}
+		})
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "Team meeting", want: "Team meeting"},
+		{name: "special characters", value: `a\b; c, d`, want: `a\\b\; c\, d`},
+		{name: "line feed", value: "one\ntwo", want: `one\ntwo`},
+		{name: "CRLF", value: "one\r\ntwo", want: `one\ntwo`},
+		{name: "lone CR", value: "one\rtwo", want: `one\ntwo`},
+		{name: "colon is not escaped", value: "10:00", want: "10:00"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, string(appendText(nil, testCase.value)))
+		})
+	}
+}
+
+func TestAppendParamValue(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		quote bool
+		want  string
+	}{
+		{name: "plain", value: "en", want: "en"},
+		{name: "comma is quoted", value: "Doe, Jane", want: `"Doe, Jane"`},
+		{name: "colon is quoted", value: "mailto:jane@example.com", want: `"mailto:jane@example.com"`},
+		{name: "semicolon is quoted", value: "a;b", want: `"a;b"`},
+		{name: "forced quotes", value: "x", quote: true, want: `"x"`},
+		{name: "double quote", value: `The "Boss"`, want: "The ^'Boss^'"},
+		{name: "caret", value: "a^b", want: "a^^b"},
+		{name: "line breaks", value: "a\nb\r\nc\rd", want: "a^nb^nc^nd"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, string(appendParamValue(nil, testCase.value, testCase.quote)))
+		})
+	}
+}
+
+func TestIsValidName(t *testing.T) {
+	testCases := []struct {
+		name string
+		want bool
+	}{
+		{name: "SUMMARY", want: true},
+		{name: "X-WR-CALNAME", want: true},
+		{name: "x-custom-2", want: true},
+		{name: "", want: false},
+		{name: "X FOO", want: false},
+		{name: "X-FOO:BAR", want: false},
+		{name: "X-FOO\r\nX-BAR", want: false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, isValidName(testCase.name))
+		})
+	}
+}
@@ -0,0 +1,342 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package encode
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michael-gallo/simpleical/icaldur"
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/rrule"
+)
+
+// Layouts of DATE and DATE-TIME values.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.4
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.5
+const (
+	dateLayout     = "20060102"
+	floatingLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+)
+
+// text writes a TEXT property without parameters, such as UID, unless the value is empty.
+func (e *encoder) text(name, value string) {
+	if value == "" {
+		return
+	}
+	e.startLine(name)
+	e.textValue(value)
+}
+
+// textProperty writes a TEXT property with its LANGUAGE and ALTREP parameters, unless the value is empty.
+func (e *encoder) textProperty(name string, text model.Text) {
+	if text.Value == "" {
+		return
+	}
+	e.startLine(name)
+	e.uriParam("ALTREP", text.AltRep)
+	e.param("LANGUAGE", text.Language)
+	e.textValue(text.Value)
+}
+
+// textProperties writes a TEXT property that may occur more than once, such as COMMENT, once per value.
+func (e *encoder) textProperties(name string, texts []model.Text) {
+	for _, text := range texts {
+		e.textProperty(name, text)
+	}
+}
+
+// textList writes a property holding a list of TEXT values, such as CATEGORIES, on a single line.
+func (e *encoder) textList(name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	e.startLine(name)
+	if e.err != nil {
+		return
+	}
+	e.line = append(e.line, ':')
+	for i, value := range values {
+		if i > 0 {
+			e.line = append(e.line, ',')
+		}
+		e.line = appendText(e.line, value)
+	}
+	e.writeLine()
+}
+
+// raw writes a property whose value is written as it is, such as STATUS or URL, unless the value is empty.
+func (e *encoder) raw(name, value string) {
+	if value == "" {
+		return
+	}
+	e.startLine(name)
+	e.rawValue(value)
+}
+
+// integer writes an INTEGER property, unless the value is 0.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.8
+func (e *encoder) integer(name string, value int) {
+	if value == 0 {
+		return
+	}
+	e.startLine(name)
+	e.rawValue(strconv.Itoa(value))
+}
+
+// uri writes a URI property, unless the URI is nil.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.13
+func (e *encoder) uri(name string, uri *url.URL) {
+	if uri == nil {
+		return
+	}
+	e.startLine(name)
+	e.rawValue(uri.String())
+}
+
+// utcTime writes a DATE-TIME property that must be in UTC, such as DTSTAMP, unless the time is zero.
+func (e *encoder) utcTime(name string, value time.Time) {
+	if value.IsZero() {
+		return
+	}
+	e.startLine(name)
+	e.rawValue(value.UTC().Format(utcLayout))
+}
+
+// floatingTime writes a DATE-TIME property as a floating time, such as the DTSTART of a STANDARD
+// sub-component, unless the time is zero.
+func (e *encoder) floatingTime(name string, value time.Time) {
+	if value.IsZero() {
+		return
+	}
+	e.startLine(name)
+	e.rawValue(value.Format(floatingLayout))
+}
+
+// dateTime writes a property that can hold a DATE or a DATE-TIME in the form it was read in, unless it is unset.
+// A DATE gets a VALUE=DATE parameter and a local time a TZID parameter.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.5
+func (e *encoder) dateTime(name string, value model.DateTime) {
+	if value.IsZero() {
+		return
+	}
+	e.startLine(name)
+	switch value.Kind {
+	case model.DateTimeDate:
+		e.param("VALUE", "DATE")
+		e.rawValue(value.Time.Format(dateLayout))
+	case model.DateTimeLocal:
+		e.param("TZID", value.TZID)
+		e.rawValue(value.Time.Format(floatingLayout))
+	case model.DateTimeFloating:
+		e.rawValue(value.Time.Format(floatingLayout))
+	default:
+		e.rawValue(value.Time.UTC().Format(utcLayout))
+	}
+}
+
+// dateTimes writes a property that may occur more than once, such as EXDATE, once per value.
+func (e *encoder) dateTimes(name string, values []model.DateTime) {
+	for _, value := range values {
+		e.dateTime(name, value)
+	}
+}
+
+// duration writes a DURATION property, unless the duration is 0.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.6
+func (e *encoder) duration(name string, value time.Duration) {
+	if value == 0 {
+		return
+	}
+	e.startLine(name)
+	e.rawValue(icaldur.FormatICalDuration(value))
+}
+
+// utcOffset writes a UTC-OFFSET property such as TZOFFSETFROM.
+// The property is required, so an offset of 0 is written too.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.14
+func (e *encoder) utcOffset(name string, value model.UTCOffset) {
+	e.startLine(name)
+	e.rawValue(value.String())
+}
+
+// recurrenceRule writes an RRULE property, unless the rule is nil.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.3
+func (e *encoder) recurrenceRule(rule *rrule.RRule) {
+	if rule == nil {
+		return
+	}
+	e.startLine("RRULE")
+	e.rawValue(rule.String())
+}
+
+// geo writes a GEO property, unless it is nil.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.6
+func (e *encoder) geo(geo *model.Geo) {
+	if geo == nil {
+		return
+	}
+	e.startLine("GEO")
+	e.rawValue(strconv.FormatFloat(geo.Lat, 'f', -1, 64) + ";" + strconv.FormatFloat(geo.Lon, 'f', -1, 64))
+}
+
+// organizer writes an ORGANIZER property, unless it is nil.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.3
+func (e *encoder) organizer(organizer *model.Organizer) {
+	if organizer == nil {
+		return
+	}
+	e.startLine("ORGANIZER")
+	e.param("CN", organizer.CommonName)
+	e.uriParam("DIR", organizer.Directory)
+	e.uriParam("SENT-BY", organizer.SentBy)
+	e.param("LANGUAGE", organizer.Language)
+	e.otherParams(organizer.OtherParams)
+	e.calAddress(organizer.CalAddress)
+}
+
+// attendees writes an ATTENDEE property per attendee.
+// RSVP is only written when it is TRUE, FALSE being its default.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.1
+func (e *encoder) attendees(attendees []model.Attendee) {
+	for _, attendee := range attendees {
+		e.startLine("ATTENDEE")
+		e.param("CUTYPE", string(attendee.CalendarUserType))
+		e.uriParam("MEMBER", attendee.Member...)
+		e.param("ROLE", string(attendee.Role))
+		e.param("PARTSTAT", string(attendee.ParticipationStatus))
+		if attendee.RSVP {
+			e.param("RSVP", "TRUE")
+		}
+		e.uriParam("DELEGATED-TO", attendee.DelegatedTo...)
+		e.uriParam("DELEGATED-FROM", attendee.DelegatedFrom...)
+		e.uriParam("SENT-BY", attendee.SentBy)
+		e.param("CN", attendee.CommonName)
+		e.uriParam("DIR", attendee.Directory)
+		e.param("LANGUAGE", attendee.Language)
+		e.otherParams(attendee.OtherParams)
+		e.calAddress(attendee.CalAddress)
+	}
+}
+
+// attachments writes an ATTACH property per attachment.
+// Inline documents are written base64 encoded with the ENCODING=BASE64 and VALUE=BINARY parameters.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.1
+func (e *encoder) attachments(attachments []model.Attachment) {
+	for _, attachment := range attachments {
+		e.startLine("ATTACH")
+		e.param("FMTTYPE", attachment.FormatType)
+		e.otherParams(attachment.OtherParams)
+		if attachment.IsInline() {
+			e.param("ENCODING", "BASE64")
+			e.param("VALUE", "BINARY")
+			e.rawValue(attachment.Base64())
+			continue
+		}
+		e.rawValue(attachment.URI.String())
+	}
+}
+
+// requestStatuses writes a REQUEST-STATUS property per status.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.3
+func (e *encoder) requestStatuses(statuses []model.RequestStatus) {
+	for _, status := range statuses {
+		e.startLine("REQUEST-STATUS")
+		e.param("LANGUAGE", status.Language)
+		if e.err != nil {
+			return
+		}
+		code := make([]string, len(status.Code))
+		for i, number := range status.Code {
+			code[i] = strconv.Itoa(number)
+		}
+		e.line = append(e.line, ':')
+		e.line = append(e.line, strings.Join(code, ".")...)
+		e.line = append(e.line, ';')
+		e.line = appendText(e.line, status.Description)
+		if status.ExtraData != "" {
+			e.line = append(e.line, ';')
+			e.line = appendText(e.line, status.ExtraData)
+		}
+		e.writeLine()
+	}
+}
+
+// relations writes a RELATED-TO property per relation.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.5
+func (e *encoder) relations(relations []model.Relation) {
+	for _, relation := range relations {
+		e.startLine("RELATED-TO")
+		e.param("RELTYPE", string(relation.Type))
+		e.otherParams(relation.OtherParams)
+		e.textValue(relation.UID)
+	}
+}
+
+// trigger writes the TRIGGER property of an alarm, unless it is unset.
+// RELATED=START is left out, as it is the default.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.6.3
+func (e *encoder) trigger(trigger model.Trigger) {
+	if trigger.IsZero() {
+		return
+	}
+	e.startLine("TRIGGER")
+	if trigger.IsAbsolute() {
+		e.param("VALUE", "DATE-TIME")
+		e.rawValue(trigger.Time.UTC().Format(utcLayout))
+		return
+	}
+	if trigger.Related != model.TriggerRelatedStart {
+		e.param("RELATED", string(trigger.Related))
+	}
+	e.rawValue(icaldur.FormatICalDuration(trigger.Duration))
+}
+
+// freeBusyTimes writes a FREEBUSY property per period.
+// FBTYPE=BUSY is left out, as it is the default.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.2.6
+func (e *encoder) freeBusyTimes(periods []model.FreeBusyTime) {
+	for _, period := range periods {
+		e.startLine("FREEBUSY")
+		if period.Status != model.FreeBusyStatusBusy {
+			e.param("FBTYPE", string(period.Status))
+		}
+		e.rawValue(period.Start.UTC().Format(utcLayout) + "/" + period.End.UTC().Format(utcLayout))
+	}
+}
+
+// extraProperties writes the X- and IANA properties the model has no dedicated field for, sorted by name.
+// Their values are written as they were read.
+func (e *encoder) extraProperties(xProps, ianaProps map[string][]model.Property) {
+	for _, props := range []map[string][]model.Property{xProps, ianaProps} {
+		for _, name := range slices.Sorted(maps.Keys(props)) {
+			for _, property := range props[name] {
+				e.property(property)
+			}
+		}
+	}
+}
+
+// property writes a property the model has no dedicated field for.
+func (e *encoder) property(property model.Property) {
+	e.startLine(property.Name)
+	e.otherParams(property.Params)
+	e.rawValue(property.Value)
+}
+
+// calAddress ends the content line with a CAL-ADDRESS value, and writes the line.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.3
+func (e *encoder) calAddress(address *url.URL) {
+	if address == nil {
+		e.require(e.name, "")
+		return
+	}
+	e.rawValue(address.String())
+}
@@ -1,2 +1,2 @@
-// Package icaldur converts iCalendar duration strings to time.Duration (RFC 5545 section 3.3.6).
+// Package icaldur converts iCalendar duration strings to time.Duration and back (RFC 5545 section 3.3.6).
 package icaldur
@@ -170,6 +170,51 @@ func ParseICalDuration(s string) (time.Duration, error) {
 	return time.Duration(sign * dur), nil
 }
 
+// FormatICalDuration formats a time.Duration as an iCal duration string according to RFC 5545 section 3.3.6,
+// the inverse of ParseICalDuration. A whole number of weeks is written in the weeks form, eg: P2W,
+// anything else as days, hours, minutes and seconds, eg: P1DT2H30M. A zero duration is written as PT0S.
+// iCal durations have no fractional seconds, so they are truncated.
+func FormatICalDuration(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	if seconds == 0 {
+		return "PT0S"
+	}
+
+	b := make([]byte, 0, 16)
+	if seconds < 0 {
+		b = append(b, '-')
+		seconds = -seconds
+	}
+	b = append(b, 'P')
+
+	const secondsPerDay = 24 * 60 * 60
+	if seconds%(7*secondsPerDay) == 0 {
+		b = strconv.AppendInt(b, seconds/(7*secondsPerDay), 10)
+		return string(append(b, 'W'))
+	}
+
+	if days := seconds / secondsPerDay; days > 0 {
+		b = strconv.AppendInt(b, days, 10)
+		b = append(b, 'D')
+	}
+	seconds %= secondsPerDay
+	if seconds == 0 {
+		return string(b)
+	}
+	b = append(b, 'T')
+	for _, unit := range []struct {
+		seconds int64
+		letter  byte
+	}{{60 * 60, 'H'}, {60, 'M'}, {1, 'S'}} {
+		if value := seconds / unit.seconds; value > 0 {
+			b = strconv.AppendInt(b, value, 10)
+			b = append(b, unit.letter)
+		}
+		seconds %= unit.seconds
+	}
+	return string(b)
+}
+
 // indexByteFrom finds the first index of b in s starting at from, or -1.
 func indexByteFrom(s string, b byte, from int) int {
 	for j := from; j < len(s); j++ {
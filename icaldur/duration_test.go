@@ -47,3 +47,32 @@ func BenchmarkParseICalDuration(b *testing.B) {
 		}
 	}
 }
+
+func TestFormatICalDuration(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{input: 0, want: "PT0S"},
+		{input: time.Hour, want: "PT1H"},
+		{input: time.Minute * 15, want: "PT15M"},
+		{input: time.Second, want: "PT1S"},
+		{input: time.Hour + time.Minute*30 + time.Second, want: "PT1H30M1S"},
+		{input: time.Hour*24*15 + time.Hour*5 + time.Second*20, want: "P15DT5H20S"},
+		{input: time.Hour * 24, want: "P1D"},
+		{input: time.Hour * 24 * 14, want: "P2W"},
+		{input: time.Hour*24*14 + time.Hour, want: "P14DT1H"},
+		{input: -time.Minute * 15, want: "-PT15M"},
+		{input: -time.Hour * 24 * 7, want: "-P1W"},
+		{input: time.Second + time.Millisecond*500, want: "PT1S"},
+		{input: time.Millisecond * 500, want: "PT0S"},
+	}
+	for _, test := range tests {
+		got := FormatICalDuration(test.input)
+		assert.Equal(t, test.want, got)
+
+		parsed, err := ParseICalDuration(got)
+		assert.NoError(t, err)
+		assert.Equal(t, test.input.Truncate(time.Second), parsed)
+	}
+}
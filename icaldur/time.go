@@ -86,3 +86,35 @@ func ParseIcalTime(value string) (time.Time, error) {
 	// All times are returned in UTC (floating times are treated as UTC per iCal spec)
 	return time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC), nil
 }
+
+// ParseIcalDate parses an iCal date string in the format YYYYMMDD, as used by DATE values.
+// The date is returned as midnight UTC.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.4
+func ParseIcalDate(value string) (time.Time, error) {
+	if len(value) != 8 {
+		return time.Time{}, ErrInvalidTimeFormat
+	}
+
+	year, err := strconv.Atoi(value[0:4])
+	if err != nil {
+		return time.Time{}, ErrInvalidTimeFormat
+	}
+
+	month, err := strconv.Atoi(value[4:6])
+	if err != nil {
+		return time.Time{}, ErrInvalidTimeFormat
+	}
+	if month < 1 || month > 12 {
+		return time.Time{}, ErrInvalidTimeValue
+	}
+
+	day, err := strconv.Atoi(value[6:8])
+	if err != nil {
+		return time.Time{}, ErrInvalidTimeFormat
+	}
+	if day < 1 || day > 31 {
+		return time.Time{}, ErrInvalidTimeValue
+	}
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
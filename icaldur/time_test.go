@@ -86,3 +86,49 @@ func BenchmarkParseIcalTime(b *testing.B) {
 		}
 	}
 }
+
+func TestParseIcalDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        time.Time
+		expectError bool
+	}{
+		{
+			name:  "Valid date",
+			input: "20250101",
+			want:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "Date time is not a date",
+			input:       "20250101T000000",
+			expectError: true,
+		},
+		{
+			name:        "Invalid month",
+			input:       "20251301",
+			expectError: true,
+		},
+		{
+			name:        "Invalid day",
+			input:       "20250100",
+			expectError: true,
+		},
+		{
+			name:        "Not a number",
+			input:       "2025AB01",
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseIcalDate(test.input)
+			if test.expectError {
+				assert.Error(t, err, "expected error for input: %s", test.input)
+				return
+			}
+			assert.NoError(t, err, "unexpected error for input: %s", test.input)
+			assert.Equal(t, test.want, got, "mismatch for input: %s", test.input)
+		})
+	}
+}
@@ -5,7 +5,6 @@
 package model
 
 import (
-	"net/url"
 	"time"
 )
 
@@ -20,6 +19,89 @@ const (
 	AlarmActionProcedure AlarmAction = "PROCEDURE"
 )
 
+// TriggerRelation represents the RELATED parameter of a relative TRIGGER,
+// which tells whether the trigger is relative to the start or the end of the parent component.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.14
+type TriggerRelation string
+
+const (
+	TriggerRelatedStart TriggerRelation = "START"
+	TriggerRelatedEnd   TriggerRelation = "END"
+)
+
+// Trigger is the value of the TRIGGER property of a VALARM.
+// A trigger is either relative, a duration from the start or end of the parent VEVENT or VTODO,
+// or absolute, a fixed UTC time given with VALUE=DATE-TIME.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.6.3
+type Trigger struct {
+	// Duration is the offset of a relative trigger, negative for a trigger before the start or end, eg: -PT15M.
+	Duration time.Duration
+
+	// Related is what a relative trigger is relative to, START if the RELATED parameter is absent.
+	// It is empty for an absolute trigger.
+	Related TriggerRelation
+
+	// Time is the UTC time of an absolute trigger, zero for a relative trigger.
+	Time time.Time
+}
+
+// IsZero reports whether the trigger is unset.
+func (t Trigger) IsZero() bool {
+	return t.Related == "" && t.Time.IsZero()
+}
+
+// IsAbsolute reports whether the trigger is a fixed time rather than relative to the parent component.
+func (t Trigger) IsAbsolute() bool {
+	return !t.Time.IsZero()
+}
+
+// EventInstant returns when the trigger fires for an alarm of the given event.
+// The end of the event is its DTEND, or its DTSTART plus DURATION. Without either it is the day after
+// a DATE DTSTART, and DTSTART itself otherwise.
+// ok is false if the trigger is relative to a DTSTART the event does not have.
+// For floating and DATE values the result is a wall clock time in time.UTC, like the values themselves.
+func (t Trigger) EventInstant(event *Event) (instant time.Time, ok bool) {
+	end := event.End
+	if end.IsZero() && !event.Start.IsZero() {
+		end = event.Start
+		switch {
+		case event.Duration != 0:
+			end.Time = end.Time.Add(event.Duration)
+		case event.Start.IsDate():
+			end.Time = end.Time.AddDate(0, 0, 1)
+		}
+	}
+	return t.instant(event.Start, end)
+}
+
+// TodoInstant returns when the trigger fires for an alarm of the given to-do.
+// The end of the to-do is its DUE, or its DTSTART plus DURATION.
+// ok is false if the trigger is relative to a start or end the to-do does not have.
+// For floating and DATE values the result is a wall clock time in time.UTC, like the values themselves.
+func (t Trigger) TodoInstant(todo *Todo) (instant time.Time, ok bool) {
+	due := todo.Due
+	if due.IsZero() && !todo.DTStart.IsZero() && todo.Duration != 0 {
+		due = todo.DTStart
+		due.Time = due.Time.Add(todo.Duration)
+	}
+	return t.instant(todo.DTStart, due)
+}
+
+// instant returns when the trigger fires for a component with the given start and end.
+func (t Trigger) instant(start, end DateTime) (time.Time, bool) {
+	if t.IsAbsolute() {
+		return t.Time, true
+	}
+	anchor := start
+	if t.Related == TriggerRelatedEnd {
+		anchor = end
+	}
+	if anchor.IsZero() {
+		return time.Time{}, false
+	}
+	return anchor.Time.Add(t.Duration), true
+}
+
 // Alarm represents a VALARM component in the iCalendar format.
 // A VALARM is a grouping of component properties that defines an alarm.
 // VALARM components are sub-components of VEVENT and VTODOs
@@ -33,12 +115,12 @@ type Alarm struct {
 	// REQUIRED, MUST NOT occur more than once
 	// Specifies when an alarm will trigger.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.6.3
-	Trigger string
+	Trigger Trigger
 
 	// OPTIONAL, MUST NOT occur more than once (for AUDIO and EMAIL actions)
 	// Provides the capability to associate a document object with an alarm.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.1
-	Attach []string
+	Attach []Attachment
 
 	// OPTIONAL, MUST NOT occur more than once (for AUDIO and EMAIL actions)
 	// Specifies a positive duration of time for repeating alarms.
@@ -48,7 +130,7 @@ type Alarm struct {
 	// OPTIONAL, MUST NOT occur more than once (for DISPLAY and EMAIL actions)
 	// Provides a more complete description of the alarm than that provided by the SUMMARY property.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.5
-	Description []string
+	Description []Text
 
 	// OPTIONAL, MUST NOT occur more than once (for AUDIO and EMAIL actions)
 	// Defines the number of times the alarm should be repeated.
@@ -58,20 +140,25 @@ type Alarm struct {
 	// OPTIONAL, MUST NOT occur more than once (for EMAIL action)
 	// Defines a short summary or subject for the alarm.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.12
-	Summary string
+	Summary Text
 
 	// OPTIONAL, MAY occur more than once (for EMAIL action, at least one required)
 	// Specifies the participants that are invited to the alarm.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.1
-	Attendees []url.URL
+	Attendees []Attendee
 
 	// OPTIONAL, MAY occur more than once
 	// A Non-Standard Property. Can be represented by any name with a X-prefix.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.2
-	XProp map[string]string
+	XProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once
 	// An IANA registered property name.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.1
-	IANAProp map[string]string
+	IANAProp map[string][]Property
+
+	// OPTIONAL, MAY occur more than once
+	// Components nested in this alarm that the model has no dedicated type for, eg: X- components.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+	OtherComponents []Component
 }
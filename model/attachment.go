@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"encoding/base64"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Attachment is the value of an ATTACH property, a document associated with a component.
+// The document is either referenced by a URI or included inline, in which case
+// the property has the ENCODING=BASE64 and VALUE=BINARY parameters.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.1
+type Attachment struct {
+	// URI locates the document, nil for an inline attachment.
+	URI *url.URL
+
+	// denoted by FMTTYPE
+	// The media type of the document, eg: application/pdf.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.8
+	FormatType string
+
+	// Parameters other than FMTTYPE, ENCODING and VALUE, eg: X- parameters.
+	OtherParams Parameters
+
+	// encoded is the base64 content of an inline attachment.
+	// Inline documents can be large, so they are kept as they were read and only decoded by Reader and Bytes.
+	encoded string
+}
+
+// NewInlineAttachment returns an inline attachment holding data, with the given media type.
+func NewInlineAttachment(data []byte, formatType string) Attachment {
+	return Attachment{FormatType: formatType, encoded: base64.StdEncoding.EncodeToString(data)}
+}
+
+// NewBase64Attachment returns an inline attachment from its base64 encoded content, as written in an
+// ATTACH property with ENCODING=BASE64. The content is not copied or decoded, so it is not validated either.
+func NewBase64Attachment(encoded, formatType string) Attachment {
+	return Attachment{FormatType: formatType, encoded: encoded}
+}
+
+// IsInline reports whether the document is included in the attachment rather than referenced by a URI.
+func (a Attachment) IsInline() bool {
+	return a.URI == nil
+}
+
+// Reader returns a reader that decodes the document of an inline attachment as it is read.
+// It reads nothing for an attachment referenced by a URI.
+func (a Attachment) Reader() io.Reader {
+	return base64.NewDecoder(base64.StdEncoding, strings.NewReader(a.encoded))
+}
+
+// Bytes returns the decoded document of an inline attachment, or nil for an attachment referenced by a URI.
+func (a Attachment) Bytes() ([]byte, error) {
+	if a.encoded == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(a.encoded)
+}
+
+// Base64 returns the base64 encoded document of an inline attachment, as it is written in an ATTACH property.
+// It is empty for an attachment referenced by a URI.
+func (a Attachment) Base64() string {
+	return a.encoded
+}
@@ -30,6 +30,16 @@ type Calendar struct {
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.7.2
 	Method string
 
+	// A Non-Standard Property. Can be represented by any name with a X-prefix, eg: X-WR-CALNAME.
+	// This is optional and repeatable.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.2
+	XProp map[string][]Property
+
+	// An IANA registered property name without a dedicated field, eg: NAME or COLOR from RFC 7986.
+	// This is optional and repeatable.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.1
+	IANAProp map[string][]Property
+
 	// TimeZones contains all VTIMEZONE components in the calendar.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.5
 	TimeZones []TimeZone
@@ -46,4 +56,9 @@ type Calendar struct {
 
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.4
 	FreeBusys []FreeBusy
+
+	// Components the model has no dedicated type for, such as VAVAILABILITY or X- components.
+	// They are kept in the order they appeared, with their properties and nested components.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+	OtherComponents []Component
 }
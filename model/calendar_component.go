@@ -0,0 +1,288 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"iter"
+	"time"
+)
+
+// CalendarComponent is a component that describes something scheduled or recorded on a calendar:
+// *Event, *Todo or *Journal. It gives access to the properties the three share, so a helper can be
+// written once for all of them. Each accessor returns the field of the same name, except GetStart,
+// which returns Event.Start or the DTStart field of a Todo or Journal.
+// Properties that differ in type between the components, such as STATUS, are not part of the interface.
+type CalendarComponent interface {
+	TopLevelComponent
+
+	// GetUID returns the UID property.
+	GetUID() string
+	// GetDTStamp returns the DTSTAMP property.
+	GetDTStamp() time.Time
+	// GetStart returns the DTSTART property.
+	GetStart() DateTime
+	// GetSequence returns the SEQUENCE property.
+	GetSequence() int
+	// GetOrganizer returns the ORGANIZER property.
+	GetOrganizer() *Organizer
+	// GetAttendees returns the ATTENDEE property.
+	GetAttendees() []Attendee
+	// GetCategories returns the CATEGORIES property.
+	GetCategories() []string
+	// GetRecurrenceID returns the RECURRENCE-ID property.
+	GetRecurrenceID() DateTime
+	// GetSummary returns the SUMMARY property.
+	GetSummary() Text
+	// GetClass returns the CLASS property.
+	GetClass() Class
+	// GetCreated returns the CREATED property.
+	GetCreated() time.Time
+	// GetLastModified returns the LAST-MODIFIED property.
+	GetLastModified() time.Time
+	// GetURL returns the URL property.
+	GetURL() string
+	// GetAttach returns the ATTACH property.
+	GetAttach() []Attachment
+	// GetComment returns the COMMENT property.
+	GetComment() []Text
+	// GetContacts returns the CONTACT property.
+	GetContacts() []Text
+	// GetExceptionDates returns the EXDATE property.
+	GetExceptionDates() []DateTime
+	// GetRdate returns the RDATE property.
+	GetRdate() []DateTime
+	// GetRelated returns the RELATED-TO property.
+	GetRelated() []Relation
+	// GetRequestStatus returns the REQUEST-STATUS property.
+	GetRequestStatus() []RequestStatus
+}
+
+var (
+	_ CalendarComponent = (*Event)(nil)
+	_ CalendarComponent = (*Todo)(nil)
+	_ CalendarComponent = (*Journal)(nil)
+)
+
+// Components returns an iterator over the events, to-dos and journals of the calendar, in that order.
+// The components are pointers into the calendar, so changes made through them are kept.
+func (c *Calendar) Components() iter.Seq[CalendarComponent] {
+	return func(yield func(CalendarComponent) bool) {
+		for i := range c.Events {
+			if !yield(&c.Events[i]) {
+				return
+			}
+		}
+		for i := range c.Todos {
+			if !yield(&c.Todos[i]) {
+				return
+			}
+		}
+		for i := range c.Journals {
+			if !yield(&c.Journals[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ComponentByUID returns the event, to-do or journal with the given UID.
+// The instances of a recurring component that were changed share its UID, so the component
+// without a RECURRENCE-ID is preferred, and the first match is returned only if there is none.
+// It reports false if no component has the UID.
+func (c *Calendar) ComponentByUID(uid string) (CalendarComponent, bool) {
+	var first CalendarComponent
+	for component := range c.Components() {
+		if component.GetUID() != uid {
+			continue
+		}
+		if component.GetRecurrenceID().IsZero() {
+			return component, true
+		}
+		if first == nil {
+			first = component
+		}
+	}
+	return first, first != nil
+}
+
+// GetUID returns the UID property of the event.
+func (e *Event) GetUID() string { return e.UID }
+
+// GetDTStamp returns the DTSTAMP property of the event.
+func (e *Event) GetDTStamp() time.Time { return e.DTStamp }
+
+// GetStart returns the DTSTART property of the event.
+func (e *Event) GetStart() DateTime { return e.Start }
+
+// GetSequence returns the SEQUENCE property of the event.
+func (e *Event) GetSequence() int { return e.Sequence }
+
+// GetOrganizer returns the ORGANIZER property of the event.
+func (e *Event) GetOrganizer() *Organizer { return e.Organizer }
+
+// GetAttendees returns the ATTENDEE property of the event.
+func (e *Event) GetAttendees() []Attendee { return e.Attendees }
+
+// GetCategories returns the CATEGORIES property of the event.
+func (e *Event) GetCategories() []string { return e.Categories }
+
+// GetRecurrenceID returns the RECURRENCE-ID property of the event.
+func (e *Event) GetRecurrenceID() DateTime { return e.RecurrenceID }
+
+// GetSummary returns the SUMMARY property of the event.
+func (e *Event) GetSummary() Text { return e.Summary }
+
+// GetClass returns the CLASS property of the event.
+func (e *Event) GetClass() Class { return e.Class }
+
+// GetCreated returns the CREATED property of the event.
+func (e *Event) GetCreated() time.Time { return e.Created }
+
+// GetLastModified returns the LAST-MODIFIED property of the event.
+func (e *Event) GetLastModified() time.Time { return e.LastModified }
+
+// GetURL returns the URL property of the event.
+func (e *Event) GetURL() string { return e.URL }
+
+// GetAttach returns the ATTACH property of the event.
+func (e *Event) GetAttach() []Attachment { return e.Attach }
+
+// GetComment returns the COMMENT property of the event.
+func (e *Event) GetComment() []Text { return e.Comment }
+
+// GetContacts returns the CONTACT property of the event.
+func (e *Event) GetContacts() []Text { return e.Contacts }
+
+// GetExceptionDates returns the EXDATE property of the event.
+func (e *Event) GetExceptionDates() []DateTime { return e.ExceptionDates }
+
+// GetRdate returns the RDATE property of the event.
+func (e *Event) GetRdate() []DateTime { return e.Rdate }
+
+// GetRelated returns the RELATED-TO property of the event.
+func (e *Event) GetRelated() []Relation { return e.Related }
+
+// GetRequestStatus returns the REQUEST-STATUS property of the event.
+func (e *Event) GetRequestStatus() []RequestStatus { return e.RequestStatus }
+
+// GetUID returns the UID property of the to-do.
+func (t *Todo) GetUID() string { return t.UID }
+
+// GetDTStamp returns the DTSTAMP property of the to-do.
+func (t *Todo) GetDTStamp() time.Time { return t.DTStamp }
+
+// GetStart returns the DTSTART property of the to-do.
+func (t *Todo) GetStart() DateTime { return t.DTStart }
+
+// GetSequence returns the SEQUENCE property of the to-do.
+func (t *Todo) GetSequence() int { return t.Sequence }
+
+// GetOrganizer returns the ORGANIZER property of the to-do.
+func (t *Todo) GetOrganizer() *Organizer { return t.Organizer }
+
+// GetAttendees returns the ATTENDEE property of the to-do.
+func (t *Todo) GetAttendees() []Attendee { return t.Attendees }
+
+// GetCategories returns the CATEGORIES property of the to-do.
+func (t *Todo) GetCategories() []string { return t.Categories }
+
+// GetRecurrenceID returns the RECURRENCE-ID property of the to-do.
+func (t *Todo) GetRecurrenceID() DateTime { return t.RecurrenceID }
+
+// GetSummary returns the SUMMARY property of the to-do.
+func (t *Todo) GetSummary() Text { return t.Summary }
+
+// GetClass returns the CLASS property of the to-do.
+func (t *Todo) GetClass() Class { return t.Class }
+
+// GetCreated returns the CREATED property of the to-do.
+func (t *Todo) GetCreated() time.Time { return t.Created }
+
+// GetLastModified returns the LAST-MODIFIED property of the to-do.
+func (t *Todo) GetLastModified() time.Time { return t.LastModified }
+
+// GetURL returns the URL property of the to-do.
+func (t *Todo) GetURL() string { return t.URL }
+
+// GetAttach returns the ATTACH property of the to-do.
+func (t *Todo) GetAttach() []Attachment { return t.Attach }
+
+// GetComment returns the COMMENT property of the to-do.
+func (t *Todo) GetComment() []Text { return t.Comment }
+
+// GetContacts returns the CONTACT property of the to-do.
+func (t *Todo) GetContacts() []Text { return t.Contacts }
+
+// GetExceptionDates returns the EXDATE property of the to-do.
+func (t *Todo) GetExceptionDates() []DateTime { return t.ExceptionDates }
+
+// GetRdate returns the RDATE property of the to-do.
+func (t *Todo) GetRdate() []DateTime { return t.Rdate }
+
+// GetRelated returns the RELATED-TO property of the to-do.
+func (t *Todo) GetRelated() []Relation { return t.Related }
+
+// GetRequestStatus returns the REQUEST-STATUS property of the to-do.
+func (t *Todo) GetRequestStatus() []RequestStatus { return t.RequestStatus }
+
+// GetUID returns the UID property of the journal.
+func (j *Journal) GetUID() string { return j.UID }
+
+// GetDTStamp returns the DTSTAMP property of the journal.
+func (j *Journal) GetDTStamp() time.Time { return j.DTStamp }
+
+// GetStart returns the DTSTART property of the journal.
+func (j *Journal) GetStart() DateTime { return j.DTStart }
+
+// GetSequence returns the SEQUENCE property of the journal.
+func (j *Journal) GetSequence() int { return j.Sequence }
+
+// GetOrganizer returns the ORGANIZER property of the journal.
+func (j *Journal) GetOrganizer() *Organizer { return j.Organizer }
+
+// GetAttendees returns the ATTENDEE property of the journal.
+func (j *Journal) GetAttendees() []Attendee { return j.Attendees }
+
+// GetCategories returns the CATEGORIES property of the journal.
+func (j *Journal) GetCategories() []string { return j.Categories }
+
+// GetRecurrenceID returns the RECURRENCE-ID property of the journal.
+func (j *Journal) GetRecurrenceID() DateTime { return j.RecurrenceID }
+
+// GetSummary returns the SUMMARY property of the journal.
+func (j *Journal) GetSummary() Text { return j.Summary }
+
+// GetClass returns the CLASS property of the journal.
+func (j *Journal) GetClass() Class { return j.Class }
+
+// GetCreated returns the CREATED property of the journal.
+func (j *Journal) GetCreated() time.Time { return j.Created }
+
+// GetLastModified returns the LAST-MODIFIED property of the journal.
+func (j *Journal) GetLastModified() time.Time { return j.LastModified }
+
+// GetURL returns the URL property of the journal.
+func (j *Journal) GetURL() string { return j.URL }
+
+// GetAttach returns the ATTACH property of the journal.
+func (j *Journal) GetAttach() []Attachment { return j.Attach }
+
+// GetComment returns the COMMENT property of the journal.
+func (j *Journal) GetComment() []Text { return j.Comment }
+
+// GetContacts returns the CONTACT property of the journal.
+func (j *Journal) GetContacts() []Text { return j.Contacts }
+
+// GetExceptionDates returns the EXDATE property of the journal.
+func (j *Journal) GetExceptionDates() []DateTime { return j.ExceptionDates }
+
+// GetRdate returns the RDATE property of the journal.
+func (j *Journal) GetRdate() []DateTime { return j.Rdate }
+
+// GetRelated returns the RELATED-TO property of the journal.
+func (j *Journal) GetRelated() []Relation { return j.Related }
+
+// GetRequestStatus returns the REQUEST-STATUS property of the journal.
+func (j *Journal) GetRequestStatus() []RequestStatus { return j.RequestStatus }
@@ -0,0 +1,302 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+)
+
+// The Clone methods below return deep copies: the copy shares no slice, map or pointer with the
+// original, so either one can be modified without affecting the other.
+// DateTime, Trigger, FreeBusyTime and UTCOffset hold no references and are copied by assignment.
+
+// Clone returns a deep copy of the calendar, or nil if the calendar is nil.
+func (c *Calendar) Clone() *Calendar {
+	if c == nil {
+		return nil
+	}
+	clone := *c
+	clone.XProp = cloneProps(c.XProp)
+	clone.IANAProp = cloneProps(c.IANAProp)
+	clone.TimeZones = clonePtrSlice(c.TimeZones, (*TimeZone).Clone)
+	clone.Events = clonePtrSlice(c.Events, (*Event).Clone)
+	clone.Todos = clonePtrSlice(c.Todos, (*Todo).Clone)
+	clone.Journals = clonePtrSlice(c.Journals, (*Journal).Clone)
+	clone.FreeBusys = clonePtrSlice(c.FreeBusys, (*FreeBusy).Clone)
+	clone.OtherComponents = cloneSlice(c.OtherComponents, Component.Clone)
+	return &clone
+}
+
+// Clone returns a deep copy of the event, or nil if the event is nil.
+func (e *Event) Clone() *Event {
+	if e == nil {
+		return nil
+	}
+	clone := *e
+	clone.Summary = e.Summary.Clone()
+	clone.Description = e.Description.Clone()
+	clone.Geo = e.Geo.Clone()
+	clone.Location = e.Location.Clone()
+	clone.Organizer = e.Organizer.Clone()
+	clone.RRule = e.RRule.Clone()
+	clone.Attach = cloneSlice(e.Attach, Attachment.Clone)
+	clone.Attendees = cloneSlice(e.Attendees, Attendee.Clone)
+	clone.Categories = slices.Clone(e.Categories)
+	clone.Comment = cloneSlice(e.Comment, Text.Clone)
+	clone.Contacts = cloneSlice(e.Contacts, Text.Clone)
+	clone.ExceptionDates = slices.Clone(e.ExceptionDates)
+	clone.RequestStatus = cloneSlice(e.RequestStatus, RequestStatus.Clone)
+	clone.Related = cloneSlice(e.Related, Relation.Clone)
+	clone.Resources = cloneSlice(e.Resources, Text.Clone)
+	clone.Rdate = slices.Clone(e.Rdate)
+	clone.XProp = cloneProps(e.XProp)
+	clone.IANAProp = cloneProps(e.IANAProp)
+	clone.Alarms = cloneSlice(e.Alarms, Alarm.Clone)
+	clone.OtherComponents = cloneSlice(e.OtherComponents, Component.Clone)
+	return &clone
+}
+
+// Clone returns a deep copy of the todo, or nil if the todo is nil.
+func (t *Todo) Clone() *Todo {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	clone.Description = cloneSlice(t.Description, Text.Clone)
+	clone.Geo = t.Geo.Clone()
+	clone.Location = t.Location.Clone()
+	clone.Organizer = t.Organizer.Clone()
+	clone.Summary = t.Summary.Clone()
+	clone.Attach = cloneSlice(t.Attach, Attachment.Clone)
+	clone.Attendees = cloneSlice(t.Attendees, Attendee.Clone)
+	clone.Categories = slices.Clone(t.Categories)
+	clone.Comment = cloneSlice(t.Comment, Text.Clone)
+	clone.Contacts = cloneSlice(t.Contacts, Text.Clone)
+	clone.ExceptionDates = slices.Clone(t.ExceptionDates)
+	clone.RequestStatus = cloneSlice(t.RequestStatus, RequestStatus.Clone)
+	clone.Related = cloneSlice(t.Related, Relation.Clone)
+	clone.Resources = cloneSlice(t.Resources, Text.Clone)
+	clone.Rdate = slices.Clone(t.Rdate)
+	clone.XProp = cloneProps(t.XProp)
+	clone.IANAProp = cloneProps(t.IANAProp)
+	clone.Alarms = cloneSlice(t.Alarms, Alarm.Clone)
+	clone.OtherComponents = cloneSlice(t.OtherComponents, Component.Clone)
+	return &clone
+}
+
+// Clone returns a deep copy of the journal, or nil if the journal is nil.
+func (j *Journal) Clone() *Journal {
+	if j == nil {
+		return nil
+	}
+	clone := *j
+	clone.Organizer = j.Organizer.Clone()
+	clone.Summary = j.Summary.Clone()
+	clone.RRule = j.RRule.Clone()
+	clone.Attach = cloneSlice(j.Attach, Attachment.Clone)
+	clone.Attendees = cloneSlice(j.Attendees, Attendee.Clone)
+	clone.Categories = slices.Clone(j.Categories)
+	clone.Comment = cloneSlice(j.Comment, Text.Clone)
+	clone.Contacts = cloneSlice(j.Contacts, Text.Clone)
+	clone.Description = cloneSlice(j.Description, Text.Clone)
+	clone.ExceptionDates = slices.Clone(j.ExceptionDates)
+	clone.Related = cloneSlice(j.Related, Relation.Clone)
+	clone.Rdate = slices.Clone(j.Rdate)
+	clone.RequestStatus = cloneSlice(j.RequestStatus, RequestStatus.Clone)
+	clone.XProp = cloneProps(j.XProp)
+	clone.IANAProp = cloneProps(j.IANAProp)
+	clone.Alarms = cloneSlice(j.Alarms, Alarm.Clone)
+	clone.OtherComponents = cloneSlice(j.OtherComponents, Component.Clone)
+	return &clone
+}
+
+// Clone returns a deep copy of the free/busy component, or nil if it is nil.
+func (f *FreeBusy) Clone() *FreeBusy {
+	if f == nil {
+		return nil
+	}
+	clone := *f
+	clone.Contact = f.Contact.Clone()
+	clone.Organizer = f.Organizer.Clone()
+	clone.Attendees = cloneSlice(f.Attendees, Attendee.Clone)
+	clone.Comment = cloneSlice(f.Comment, Text.Clone)
+	clone.FreeBusy = slices.Clone(f.FreeBusy)
+	clone.RequestStatus = cloneSlice(f.RequestStatus, RequestStatus.Clone)
+	clone.XProp = cloneProps(f.XProp)
+	clone.IANAProp = cloneProps(f.IANAProp)
+	clone.OtherComponents = cloneSlice(f.OtherComponents, Component.Clone)
+	return &clone
+}
+
+// Clone returns a deep copy of the time zone, or nil if the time zone is nil.
+func (tz *TimeZone) Clone() *TimeZone {
+	if tz == nil {
+		return nil
+	}
+	clone := *tz
+	clone.TimeZoneURL = cloneURL(tz.TimeZoneURL)
+	clone.Standard = cloneSlice(tz.Standard, TimeZoneProperty.Clone)
+	clone.Daylight = cloneSlice(tz.Daylight, TimeZoneProperty.Clone)
+	clone.XProp = cloneProps(tz.XProp)
+	clone.IANAProp = cloneProps(tz.IANAProp)
+	clone.OtherComponents = cloneSlice(tz.OtherComponents, Component.Clone)
+	return &clone
+}
+
+// Clone returns a deep copy of the STANDARD or DAYLIGHT sub-component.
+func (tzp TimeZoneProperty) Clone() TimeZoneProperty {
+	tzp.Comment = cloneSlice(tzp.Comment, Text.Clone)
+	tzp.Rdate = slices.Clone(tzp.Rdate)
+	tzp.TimeZoneName = slices.Clone(tzp.TimeZoneName)
+	tzp.RRule = tzp.RRule.Clone()
+	tzp.XProp = cloneProps(tzp.XProp)
+	tzp.IANAProp = cloneProps(tzp.IANAProp)
+	tzp.OtherComponents = cloneSlice(tzp.OtherComponents, Component.Clone)
+	return tzp
+}
+
+// Clone returns a deep copy of the alarm.
+func (a Alarm) Clone() Alarm {
+	a.Attach = cloneSlice(a.Attach, Attachment.Clone)
+	a.Description = cloneSlice(a.Description, Text.Clone)
+	a.Summary = a.Summary.Clone()
+	a.Attendees = cloneSlice(a.Attendees, Attendee.Clone)
+	a.XProp = cloneProps(a.XProp)
+	a.IANAProp = cloneProps(a.IANAProp)
+	a.OtherComponents = cloneSlice(a.OtherComponents, Component.Clone)
+	return a
+}
+
+// Clone returns a deep copy of the component and its sub-components.
+func (c Component) Clone() Component {
+	c.Properties = cloneSlice(c.Properties, Property.Clone)
+	c.Components = cloneSlice(c.Components, Component.Clone)
+	return c
+}
+
+// Clone returns a deep copy of the property.
+func (p Property) Clone() Property {
+	p.Params = p.Params.Clone()
+	return p
+}
+
+// Clone returns a deep copy of the parameters, or nil if p is nil.
+func (p Parameters) Clone() Parameters {
+	if p == nil {
+		return nil
+	}
+	clone := make(Parameters, len(p))
+	for name, values := range p {
+		clone[name] = slices.Clone(values)
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the text.
+func (t Text) Clone() Text {
+	t.AltRep = cloneURL(t.AltRep)
+	return t
+}
+
+// Clone returns a copy of the position, or nil if g is nil.
+func (g *Geo) Clone() *Geo {
+	if g == nil {
+		return nil
+	}
+	clone := *g
+	return &clone
+}
+
+// Clone returns a deep copy of the organizer, or nil if the organizer is nil.
+func (o *Organizer) Clone() *Organizer {
+	if o == nil {
+		return nil
+	}
+	clone := *o
+	clone.CalAddress = cloneURL(o.CalAddress)
+	clone.Directory = cloneURL(o.Directory)
+	clone.SentBy = cloneURL(o.SentBy)
+	clone.OtherParams = o.OtherParams.Clone()
+	return &clone
+}
+
+// Clone returns a deep copy of the attendee.
+func (a Attendee) Clone() Attendee {
+	a.CalAddress = cloneURL(a.CalAddress)
+	a.Member = cloneSlice(a.Member, cloneURL)
+	a.DelegatedTo = cloneSlice(a.DelegatedTo, cloneURL)
+	a.DelegatedFrom = cloneSlice(a.DelegatedFrom, cloneURL)
+	a.SentBy = cloneURL(a.SentBy)
+	a.Directory = cloneURL(a.Directory)
+	a.OtherParams = a.OtherParams.Clone()
+	return a
+}
+
+// Clone returns a deep copy of the attachment.
+func (a Attachment) Clone() Attachment {
+	a.URI = cloneURL(a.URI)
+	a.OtherParams = a.OtherParams.Clone()
+	return a
+}
+
+// Clone returns a deep copy of the request status.
+func (r RequestStatus) Clone() RequestStatus {
+	r.Code = slices.Clone(r.Code)
+	return r
+}
+
+// Clone returns a deep copy of the relation.
+func (r Relation) Clone() Relation {
+	r.OtherParams = r.OtherParams.Clone()
+	return r
+}
+
+// cloneURL returns a copy of u, or nil if u is nil.
+// The Userinfo is shared as it cannot be modified once created.
+func cloneURL(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+	clone := *u
+	return &clone
+}
+
+// cloneSlice returns a new slice holding a clone of every element of s, or nil if s is nil.
+func cloneSlice[T any](s []T, clone func(T) T) []T {
+	if s == nil {
+		return nil
+	}
+	clones := make([]T, len(s))
+	for i, element := range s {
+		clones[i] = clone(element)
+	}
+	return clones
+}
+
+// clonePtrSlice is cloneSlice for element types whose Clone method has a pointer receiver.
+func clonePtrSlice[T any](s []T, clone func(*T) *T) []T {
+	if s == nil {
+		return nil
+	}
+	clones := make([]T, len(s))
+	for i := range s {
+		clones[i] = *clone(&s[i])
+	}
+	return clones
+}
+
+// cloneProps returns a deep copy of an XProp or IANAProp map, or nil if props is nil.
+func cloneProps(props map[string][]Property) map[string][]Property {
+	if props == nil {
+		return nil
+	}
+	clone := maps.Clone(props)
+	for name, values := range clone {
+		clone[name] = cloneSlice(values, Property.Clone)
+	}
+	return clone
+}
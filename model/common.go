@@ -6,8 +6,118 @@ package model
 
 import (
 	"net/url"
+	"strings"
 )
 
+// Parameters holds the parameters of a content line keyed by upper-cased parameter name.
+// A parameter can have several values, eg: MEMBER="mailto:a@example.com","mailto:b@example.com",
+// and the values of a name repeated on the same line are appended in order.
+// Values are unquoted and RFC 6868 caret escapes are decoded.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2
+// See: https://datatracker.ietf.org/doc/html/rfc6868
+type Parameters map[string][]string
+
+// Get returns the first value of the named parameter, or "" if it is not set.
+// Parameter names are case-insensitive.
+func (p Parameters) Get(name string) string {
+	values := p.Values(name)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Values returns every value of the named parameter in the order they appeared, or nil if it is not set.
+// Parameter names are case-insensitive.
+func (p Parameters) Values(name string) []string {
+	if values, ok := p[name]; ok {
+		return values
+	}
+	return p[strings.ToUpper(name)]
+}
+
+// Text is the value of a TEXT property such as SUMMARY, DESCRIPTION or LOCATION,
+// together with the parameters that describe it.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.11
+type Text struct {
+	// Value is the text itself.
+	Value string
+
+	// denoted by LANGUAGE
+	// The language of Value, eg: de.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.10
+	Language string
+
+	// denoted by ALTREP
+	// An alternate representation of Value, such as an HTML document.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.1
+	AltRep *url.URL
+}
+
+// String returns the value of the text.
+func (t Text) String() string {
+	return t.Value
+}
+
+// UnescapeText decodes the backslash escapes of a TEXT value.
+// `\\`, `\;`, `\,` and `\n` (or `\N`) are decoded; any other backslash is kept as written.
+// Values without a backslash are returned as-is without allocating.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.11
+func UnescapeText(value string) string {
+	firstEscape := strings.IndexByte(value, '\\')
+	if firstEscape == -1 {
+		return value
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(value))
+	builder.WriteString(value[:firstEscape])
+	for i := firstEscape; i < len(value); i++ {
+		character := value[i]
+		if character != '\\' || i == len(value)-1 {
+			builder.WriteByte(character)
+			continue
+		}
+		i++
+		switch value[i] {
+		case '\\', ';', ',':
+			builder.WriteByte(value[i])
+		case 'n', 'N':
+			builder.WriteByte('\n')
+		default:
+			builder.WriteByte('\\')
+			builder.WriteByte(value[i])
+		}
+	}
+	return builder.String()
+}
+
+// Class represents the access classification of a VEVENT, VTODO or VJOURNAL, the CLASS property.
+// Values other than the ones below are experimental or IANA registered classes.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.3
+type Class string
+
+const (
+	ClassPublic       Class = "PUBLIC"
+	ClassPrivate      Class = "PRIVATE"
+	ClassConfidential Class = "CONFIDENTIAL"
+)
+
+// Geo represents a GEO property, the global position of the activity of a VEVENT or VTODO.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.6
+type Geo struct {
+	// Lat is the latitude in decimal degrees, from -90 to 90.
+	Lat float64
+
+	// Lon is the longitude in decimal degrees, from -180 to 180.
+	Lon float64
+}
+
+// IsValid reports whether the latitude and longitude are within their ranges.
+func (g Geo) IsValid() bool {
+	return g.Lat >= -90 && g.Lat <= 90 && g.Lon >= -180 && g.Lon <= 180
+}
+
 // Organizer represents an ORGANIZER component in the iCalendar format, used in VEVENT, VTODO, and VJOURNAL
 // for more information see https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.3
 type Organizer struct {
@@ -33,5 +143,179 @@ type Organizer struct {
 	// See: https://datatracker.ietf.org/doc/html/rfc5646
 	Language string
 
-	OtherParams map[string]string
+	// Parameters other than the ones above, eg: X- parameters.
+	OtherParams Parameters
+}
+
+// AttendeeRole represents the participation role of an attendee, the ROLE parameter.
+// Values other than the ones below are experimental or IANA registered roles.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.16
+type AttendeeRole string
+
+const (
+	AttendeeRoleChair          AttendeeRole = "CHAIR"
+	AttendeeRoleRequired       AttendeeRole = "REQ-PARTICIPANT"
+	AttendeeRoleOptional       AttendeeRole = "OPT-PARTICIPANT"
+	AttendeeRoleNonParticipant AttendeeRole = "NON-PARTICIPANT"
+)
+
+// ParticipationStatus represents the participation status of an attendee, the PARTSTAT parameter.
+// Not every status is valid in every component: ACCEPTED, DECLINED and TENTATIVE apply to events,
+// COMPLETED and IN-PROCESS only to to-dos.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.12
+type ParticipationStatus string
+
+const (
+	ParticipationStatusNeedsAction ParticipationStatus = "NEEDS-ACTION"
+	ParticipationStatusAccepted    ParticipationStatus = "ACCEPTED"
+	ParticipationStatusDeclined    ParticipationStatus = "DECLINED"
+	ParticipationStatusTentative   ParticipationStatus = "TENTATIVE"
+	ParticipationStatusDelegated   ParticipationStatus = "DELEGATED"
+	ParticipationStatusCompleted   ParticipationStatus = "COMPLETED"
+	ParticipationStatusInProcess   ParticipationStatus = "IN-PROCESS"
+)
+
+// CalendarUserType represents the kind of calendar user an attendee is, the CUTYPE parameter.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.3
+type CalendarUserType string
+
+const (
+	CalendarUserTypeIndividual CalendarUserType = "INDIVIDUAL"
+	CalendarUserTypeGroup      CalendarUserType = "GROUP"
+	CalendarUserTypeResource   CalendarUserType = "RESOURCE"
+	CalendarUserTypeRoom       CalendarUserType = "ROOM"
+	CalendarUserTypeUnknown    CalendarUserType = "UNKNOWN"
+)
+
+// Attendee represents an ATTENDEE property in the iCalendar format, used in VEVENT, VTODO, VJOURNAL, VFREEBUSY and VALARM.
+// Parameters that are absent are left empty, the comment of each field gives the value the spec assumes then.
+// for more information see https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.1
+type Attendee struct {
+	// Note: Any Valid URI, usually a mailto: URI
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.3
+	CalAddress *url.URL
+
+	// denoted by CN
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.2
+	CommonName string
+
+	// denoted by CUTYPE, INDIVIDUAL if empty
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.3
+	CalendarUserType CalendarUserType
+
+	// denoted by MEMBER
+	// The groups or list memberships of the attendee.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.11
+	Member []*url.URL
+
+	// denoted by ROLE, REQ-PARTICIPANT if empty
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.16
+	Role AttendeeRole
+
+	// denoted by PARTSTAT, NEEDS-ACTION if empty
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.12
+	ParticipationStatus ParticipationStatus
+
+	// denoted by RSVP, false if absent
+	// Whether a reply is expected from the attendee.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.17
+	RSVP bool
+
+	// denoted by DELEGATED-TO
+	// The calendar users the attendee delegated participation to.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.5
+	DelegatedTo []*url.URL
+
+	// denoted by DELEGATED-FROM
+	// The calendar users that delegated participation to the attendee.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.4
+	DelegatedFrom []*url.URL
+
+	// denoted by SENT-BY
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.18
+	SentBy *url.URL
+
+	// denoted by DIR
+	// A directory entry reference
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.6
+	Directory *url.URL
+
+	// denoted by LANGUAGE
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.10
+	Language string
+
+	// Parameters other than the ones above, eg: X- parameters.
+	OtherParams Parameters
+}
+
+// RequestStatus represents a REQUEST-STATUS property, the outcome of a scheduling request as reported in an iTIP reply.
+// eg: REQUEST-STATUS:3.7;Invalid calendar user;mailto:jsmith@example.com
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.3
+// See: https://datatracker.ietf.org/doc/html/rfc5546#section-3.6
+type RequestStatus struct {
+	// Code is the hierarchical status code, eg: 3.7 is []int{3, 7}.
+	// The first number is the class of the status, see Class.
+	Code []int
+
+	// Description is the human readable description of the status, eg: Invalid calendar user.
+	Description string
+
+	// ExtraData is the optional data about the status, such as the property or value that caused it.
+	ExtraData string
+
+	// denoted by LANGUAGE
+	// The language of Description.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.10
+	Language string
+}
+
+// Class returns the class of the status: 1 for a preliminary success, 2 for a success,
+// 3 for a client error and 4 for a scheduling error. It is 0 for a status without a code.
+func (s RequestStatus) Class() int {
+	if len(s.Code) == 0 {
+		return 0
+	}
+	return s.Code[0]
+}
+
+// IsSuccess reports whether the request succeeded as is, status 2.0.
+func (s RequestStatus) IsSuccess() bool {
+	if s.Class() != 2 {
+		return false
+	}
+	for _, code := range s.Code[1:] {
+		if code != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFallback reports whether the request succeeded after a fallback, such as an ignored property or
+// parameter, any 2.x status other than 2.0.
+func (s RequestStatus) IsFallback() bool {
+	return s.Class() == 2 && !s.IsSuccess()
+}
+
+// IsFailure reports whether the request failed, a 3.x client error or a 4.x scheduling error.
+func (s RequestStatus) IsFailure() bool {
+	class := s.Class()
+	return class == 3 || class == 4
+}
+
+// Property is a single content line that the model has no dedicated field for,
+// such as a non-standard X- property or an IANA registered property.
+// Components keep these in their XProp and IANAProp maps, keyed by Name.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.1
+type Property struct {
+	// The property name, eg: X-MICROSOFT-CDO-BUSYSTATUS.
+	Name string
+
+	// The property parameters keyed by parameter name, nil if there are none.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2
+	Params Parameters
+
+	// The property value exactly as it appeared in the input.
+	// The value type of an unknown property is not known, so TEXT escapes are not decoded.
+	Value string
 }
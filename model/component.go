@@ -0,0 +1,42 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package model
+
+// Component is a generic iCalendar component that the model has no dedicated type for,
+// such as VAVAILABILITY (RFC 7953), VLOCATION (RFC 9073), VPOLL (RFC 9073) or any X- component.
+// Properties are kept in the order they appeared, so the component can be written back out unchanged.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+type Component struct {
+	// The component name as it appeared on the BEGIN line, eg: VAVAILABILITY.
+	Name string
+
+	// The properties of the component in the order they appeared.
+	// Values are kept exactly as they appeared in the input.
+	Properties []Property
+
+	// Sub-components nested inside this component, in the order they appeared.
+	// Nested components are always generic, even when their name matches a component known to the model.
+	Components []Component
+}
+
+// TopLevelComponent is a component that can appear directly inside a VCALENDAR:
+// *Event, *Todo, *Journal, *FreeBusy, *TimeZone, or *Component for a component without a dedicated type.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+type TopLevelComponent interface {
+	// ComponentName returns the name of the component as it appears on its BEGIN line, eg: VEVENT.
+	ComponentName() string
+}
+
+func (*Event) ComponentName() string { return string(SectionTokenVEvent) }
+
+func (*Todo) ComponentName() string { return string(SectionTokenVTodo) }
+
+func (*Journal) ComponentName() string { return string(SectionTokenVJournal) }
+
+func (*FreeBusy) ComponentName() string { return string(SectionTokenVFreebusy) }
+
+func (*TimeZone) ComponentName() string { return string(SectionTokenVTimezone) }
+
+func (c *Component) ComponentName() string { return c.Name }
@@ -0,0 +1,72 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package model
+
+import "time"
+
+// DateTimeKind tells which of the forms allowed by RFC 5545 a DATE or DATE-TIME value was written in.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.5
+type DateTimeKind int
+
+const (
+	// DateTimeUTC is a DATE-TIME in UTC, eg: 19980119T070000Z.
+	DateTimeUTC DateTimeKind = iota
+	// DateTimeFloating is a DATE-TIME without a time zone, eg: 19980118T230000.
+	// It refers to the same wall clock time in every time zone, so it is not a single instant.
+	DateTimeFloating
+	// DateTimeLocal is a DATE-TIME with a TZID parameter, eg: DTSTART;TZID=America/New_York:19980119T020000.
+	DateTimeLocal
+	// DateTimeDate is a DATE without a time of day, eg: DTSTART;VALUE=DATE:19970714, as used by all-day events.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.4
+	DateTimeDate
+)
+
+// String returns the name of the kind.
+func (k DateTimeKind) String() string {
+	switch k {
+	case DateTimeUTC:
+		return "UTC"
+	case DateTimeFloating:
+		return "floating"
+	case DateTimeLocal:
+		return "local"
+	case DateTimeDate:
+		return "date"
+	default:
+		return "unknown"
+	}
+}
+
+// DateTime is the value of a property that can hold either a DATE or a DATE-TIME, such as DTSTART, DTEND or DUE.
+type DateTime struct {
+	// Time holds the value.
+	// For DateTimeUTC it is in time.UTC.
+	// For DateTimeLocal it is in the location of the TZID, which is resolved against the VTIMEZONE
+	// components of the calendar first and the IANA time zone database second.
+	// For DateTimeFloating and DateTimeDate, and for a TZID that could not be resolved, it holds the
+	// wall clock time, or midnight of the date, in time.UTC.
+	Time time.Time
+
+	// Kind is the form the value was written in.
+	Kind DateTimeKind
+
+	// TZID is the TZID parameter of a DateTimeLocal value.
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.19
+	TZID string
+
+	// Unresolved is set on a DateTimeLocal value whose TZID matched neither a VTIMEZONE of the calendar
+	// nor a time zone of the IANA database, in which case Time holds the wall clock time in time.UTC.
+	Unresolved bool
+}
+
+// IsZero reports whether the value is unset.
+func (d DateTime) IsZero() bool {
+	return d.Time.IsZero()
+}
+
+// IsDate reports whether the value is a DATE without a time of day.
+func (d DateTime) IsDate() bool {
+	return d.Kind == DateTimeDate
+}
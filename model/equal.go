@@ -0,0 +1,476 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// The Equal methods below compare by meaning rather than by representation:
+//   - DATE-TIME values that denote an instant are equal when they denote the same instant,
+//     whatever location they are expressed in.
+//   - Properties and components that may occur more than once, and list values such as CATEGORIES,
+//     are equal when they hold the same values in any order.
+//   - TEXT values are compared after decoding their backslash escapes, so a value parsed with
+//     parse.WithRawText equals the same value parsed without it.
+//   - Parameter names, LANGUAGE tags and enumerated values that RFC 5545 defines as case-insensitive
+//     are compared case-insensitively, and an unset parameter equals its default value.
+//   - Events, to-dos, journals and free/busy components are only compared with those that share their UID,
+//     and time zones with those that share their TZID, so comparing large calendars stays fast.
+
+// Equal reports whether two calendars hold the same properties and components.
+func (c *Calendar) Equal(other *Calendar) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return c.Version == other.Version &&
+		c.ProdID == other.ProdID &&
+		equalEnum(c.CalScale, other.CalScale, "GREGORIAN") &&
+		strings.EqualFold(c.Method, other.Method) &&
+		equalProps(c.XProp, other.XProp) &&
+		equalProps(c.IANAProp, other.IANAProp) &&
+		equalByKey(c.TimeZones, other.TimeZones, func(tz *TimeZone) string { return tz.TimeZoneID }, (*TimeZone).Equal) &&
+		equalByKey(c.Events, other.Events, (*Event).GetUID, (*Event).Equal) &&
+		equalByKey(c.Todos, other.Todos, (*Todo).GetUID, (*Todo).Equal) &&
+		equalByKey(c.Journals, other.Journals, (*Journal).GetUID, (*Journal).Equal) &&
+		equalByKey(c.FreeBusys, other.FreeBusys, func(f *FreeBusy) string { return f.UID }, (*FreeBusy).Equal) &&
+		equalUnordered(c.OtherComponents, other.OtherComponents, Component.Equal)
+}
+
+// Equal reports whether two events hold the same properties and sub-components.
+func (e *Event) Equal(other *Event) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return e.DTStamp.Equal(other.DTStamp) &&
+		e.UID == other.UID &&
+		e.Start.Equal(other.Start) &&
+		equalEnum(e.Class, other.Class, "") &&
+		e.Created.Equal(other.Created) &&
+		e.Summary.Equal(other.Summary) &&
+		e.Description.Equal(other.Description) &&
+		e.Geo.Equal(other.Geo) &&
+		e.LastModified.Equal(other.LastModified) &&
+		e.Location.Equal(other.Location) &&
+		e.Organizer.Equal(other.Organizer) &&
+		e.Priority == other.Priority &&
+		e.Sequence == other.Sequence &&
+		equalEnum(e.Status, other.Status, "") &&
+		equalEnum(e.Transp, other.Transp, "") &&
+		e.URL == other.URL &&
+		e.RecurrenceID.Equal(other.RecurrenceID) &&
+		e.RRule.Equal(other.RRule) &&
+		e.End.Equal(other.End) &&
+		e.Duration == other.Duration &&
+		equalUnordered(e.Attach, other.Attach, Attachment.Equal) &&
+		equalUnordered(e.Attendees, other.Attendees, Attendee.Equal) &&
+		equalUnordered(e.Categories, other.Categories, equalText) &&
+		equalUnordered(e.Comment, other.Comment, Text.Equal) &&
+		equalUnordered(e.Contacts, other.Contacts, Text.Equal) &&
+		equalUnordered(e.ExceptionDates, other.ExceptionDates, DateTime.Equal) &&
+		equalUnordered(e.RequestStatus, other.RequestStatus, RequestStatus.Equal) &&
+		equalUnordered(e.Related, other.Related, Relation.Equal) &&
+		equalUnordered(e.Resources, other.Resources, Text.Equal) &&
+		equalUnordered(e.Rdate, other.Rdate, DateTime.Equal) &&
+		equalProps(e.XProp, other.XProp) &&
+		equalProps(e.IANAProp, other.IANAProp) &&
+		equalUnordered(e.Alarms, other.Alarms, Alarm.Equal) &&
+		equalUnordered(e.OtherComponents, other.OtherComponents, Component.Equal)
+}
+
+// Equal reports whether two todos hold the same properties and sub-components.
+func (t *Todo) Equal(other *Todo) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.DTStamp.Equal(other.DTStamp) &&
+		t.UID == other.UID &&
+		equalEnum(t.Class, other.Class, "") &&
+		t.Completed.Equal(other.Completed) &&
+		t.Created.Equal(other.Created) &&
+		equalUnordered(t.Description, other.Description, Text.Equal) &&
+		t.DTStart.Equal(other.DTStart) &&
+		t.Due.Equal(other.Due) &&
+		t.Duration == other.Duration &&
+		t.Geo.Equal(other.Geo) &&
+		t.LastModified.Equal(other.LastModified) &&
+		t.Location.Equal(other.Location) &&
+		t.Organizer.Equal(other.Organizer) &&
+		t.PercentComplete == other.PercentComplete &&
+		t.Priority == other.Priority &&
+		t.RecurrenceID.Equal(other.RecurrenceID) &&
+		t.Sequence == other.Sequence &&
+		equalEnum(t.Status, other.Status, "") &&
+		t.Summary.Equal(other.Summary) &&
+		equalEnum(t.Transp, other.Transp, "") &&
+		t.URL == other.URL &&
+		equalUnordered(t.Attach, other.Attach, Attachment.Equal) &&
+		equalUnordered(t.Attendees, other.Attendees, Attendee.Equal) &&
+		equalUnordered(t.Categories, other.Categories, equalText) &&
+		equalUnordered(t.Comment, other.Comment, Text.Equal) &&
+		equalUnordered(t.Contacts, other.Contacts, Text.Equal) &&
+		equalUnordered(t.ExceptionDates, other.ExceptionDates, DateTime.Equal) &&
+		equalUnordered(t.RequestStatus, other.RequestStatus, RequestStatus.Equal) &&
+		equalUnordered(t.Related, other.Related, Relation.Equal) &&
+		equalUnordered(t.Resources, other.Resources, Text.Equal) &&
+		equalUnordered(t.Rdate, other.Rdate, DateTime.Equal) &&
+		equalProps(t.XProp, other.XProp) &&
+		equalProps(t.IANAProp, other.IANAProp) &&
+		equalUnordered(t.Alarms, other.Alarms, Alarm.Equal) &&
+		equalUnordered(t.OtherComponents, other.OtherComponents, Component.Equal)
+}
+
+// Equal reports whether two journals hold the same properties and sub-components.
+func (j *Journal) Equal(other *Journal) bool {
+	if j == nil || other == nil {
+		return j == other
+	}
+	return j.DTStamp.Equal(other.DTStamp) &&
+		j.UID == other.UID &&
+		equalEnum(j.Class, other.Class, "") &&
+		j.Created.Equal(other.Created) &&
+		j.DTStart.Equal(other.DTStart) &&
+		j.LastModified.Equal(other.LastModified) &&
+		j.Organizer.Equal(other.Organizer) &&
+		j.RecurrenceID.Equal(other.RecurrenceID) &&
+		j.Sequence == other.Sequence &&
+		equalEnum(j.Status, other.Status, "") &&
+		j.Summary.Equal(other.Summary) &&
+		j.URL == other.URL &&
+		j.RRule.Equal(other.RRule) &&
+		equalUnordered(j.Attach, other.Attach, Attachment.Equal) &&
+		equalUnordered(j.Attendees, other.Attendees, Attendee.Equal) &&
+		equalUnordered(j.Categories, other.Categories, equalText) &&
+		equalUnordered(j.Comment, other.Comment, Text.Equal) &&
+		equalUnordered(j.Contacts, other.Contacts, Text.Equal) &&
+		equalUnordered(j.Description, other.Description, Text.Equal) &&
+		equalUnordered(j.ExceptionDates, other.ExceptionDates, DateTime.Equal) &&
+		equalUnordered(j.Related, other.Related, Relation.Equal) &&
+		equalUnordered(j.Rdate, other.Rdate, DateTime.Equal) &&
+		equalUnordered(j.RequestStatus, other.RequestStatus, RequestStatus.Equal) &&
+		equalProps(j.XProp, other.XProp) &&
+		equalProps(j.IANAProp, other.IANAProp) &&
+		equalUnordered(j.Alarms, other.Alarms, Alarm.Equal) &&
+		equalUnordered(j.OtherComponents, other.OtherComponents, Component.Equal)
+}
+
+// Equal reports whether two free/busy components hold the same properties and sub-components.
+func (f *FreeBusy) Equal(other *FreeBusy) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+	return f.DTStamp.Equal(other.DTStamp) &&
+		f.UID == other.UID &&
+		f.Contact.Equal(other.Contact) &&
+		f.DTStart.Equal(other.DTStart) &&
+		f.DTEnd.Equal(other.DTEnd) &&
+		f.Organizer.Equal(other.Organizer) &&
+		f.URL == other.URL &&
+		equalUnordered(f.Attendees, other.Attendees, Attendee.Equal) &&
+		equalUnordered(f.Comment, other.Comment, Text.Equal) &&
+		equalUnordered(f.FreeBusy, other.FreeBusy, FreeBusyTime.Equal) &&
+		equalUnordered(f.RequestStatus, other.RequestStatus, RequestStatus.Equal) &&
+		equalProps(f.XProp, other.XProp) &&
+		equalProps(f.IANAProp, other.IANAProp) &&
+		equalUnordered(f.OtherComponents, other.OtherComponents, Component.Equal)
+}
+
+// Equal reports whether two periods cover the same time with the same FBTYPE.
+// An unset FBTYPE equals BUSY, its default.
+func (f FreeBusyTime) Equal(other FreeBusyTime) bool {
+	return f.Start.Equal(other.Start) &&
+		f.End.Equal(other.End) &&
+		equalEnum(f.Status, other.Status, FreeBusyStatusBusy)
+}
+
+// Equal reports whether two time zones hold the same properties and sub-components.
+func (tz *TimeZone) Equal(other *TimeZone) bool {
+	if tz == nil || other == nil {
+		return tz == other
+	}
+	return tz.TimeZoneID == other.TimeZoneID &&
+		tz.LastMod.Equal(other.LastMod) &&
+		equalURL(tz.TimeZoneURL, other.TimeZoneURL) &&
+		equalUnordered(tz.Standard, other.Standard, TimeZoneProperty.Equal) &&
+		equalUnordered(tz.Daylight, other.Daylight, TimeZoneProperty.Equal) &&
+		equalProps(tz.XProp, other.XProp) &&
+		equalProps(tz.IANAProp, other.IANAProp) &&
+		equalUnordered(tz.OtherComponents, other.OtherComponents, Component.Equal)
+}
+
+// Equal reports whether two STANDARD or DAYLIGHT sub-components hold the same properties.
+func (tzp TimeZoneProperty) Equal(other TimeZoneProperty) bool {
+	return tzp.TimeZoneOffsetFrom == other.TimeZoneOffsetFrom &&
+		tzp.TimeZoneOffsetTo == other.TimeZoneOffsetTo &&
+		tzp.DTStart.Equal(other.DTStart) &&
+		equalUnordered(tzp.Comment, other.Comment, Text.Equal) &&
+		equalUnordered(tzp.Rdate, other.Rdate, time.Time.Equal) &&
+		equalUnordered(tzp.TimeZoneName, other.TimeZoneName, equalText) &&
+		tzp.RRule.Equal(other.RRule) &&
+		equalProps(tzp.XProp, other.XProp) &&
+		equalProps(tzp.IANAProp, other.IANAProp) &&
+		equalUnordered(tzp.OtherComponents, other.OtherComponents, Component.Equal)
+}
+
+// Equal reports whether two alarms hold the same properties and sub-components.
+func (a Alarm) Equal(other Alarm) bool {
+	return equalEnum(a.Action, other.Action, "") &&
+		a.Trigger.Equal(other.Trigger) &&
+		equalUnordered(a.Attach, other.Attach, Attachment.Equal) &&
+		a.Duration == other.Duration &&
+		equalUnordered(a.Description, other.Description, Text.Equal) &&
+		a.Repeat == other.Repeat &&
+		a.Summary.Equal(other.Summary) &&
+		equalUnordered(a.Attendees, other.Attendees, Attendee.Equal) &&
+		equalProps(a.XProp, other.XProp) &&
+		equalProps(a.IANAProp, other.IANAProp) &&
+		equalUnordered(a.OtherComponents, other.OtherComponents, Component.Equal)
+}
+
+// Equal reports whether two triggers fire at the same point.
+func (t Trigger) Equal(other Trigger) bool {
+	return t.Duration == other.Duration && equalEnum(t.Related, other.Related, "") && t.Time.Equal(other.Time)
+}
+
+// Equal reports whether two components have the same name, properties and sub-components.
+func (c Component) Equal(other Component) bool {
+	return strings.EqualFold(c.Name, other.Name) &&
+		equalUnordered(c.Properties, other.Properties, Property.Equal) &&
+		equalUnordered(c.Components, other.Components, Component.Equal)
+}
+
+// Equal reports whether two properties have the same name, parameters and value.
+// The value is compared as written since its type is not known.
+func (p Property) Equal(other Property) bool {
+	return strings.EqualFold(p.Name, other.Name) && p.Params.Equal(other.Params) && p.Value == other.Value
+}
+
+// Equal reports whether two parameter sets hold the same names with the same values in any order.
+// Parameter names are case-insensitive, and a nil set equals an empty one.
+func (p Parameters) Equal(other Parameters) bool {
+	a, b := p.byUpperName(), other.byUpperName()
+	if len(a) != len(b) {
+		return false
+	}
+	for name, values := range a {
+		otherValues, ok := b[name]
+		if !ok || !equalUnordered(values, otherValues, equalString) {
+			return false
+		}
+	}
+	return true
+}
+
+// byUpperName returns the parameter values by upper-cased name, merging the names that only differ in case.
+func (p Parameters) byUpperName() map[string][]string {
+	upper := make(map[string][]string, len(p))
+	for name, values := range p {
+		name = strings.ToUpper(name)
+		upper[name] = append(upper[name], values...)
+	}
+	return upper
+}
+
+// Equal reports whether two values denote the same date or time.
+// UTC values, and local values whose TZID was resolved, are compared as instants, so the same
+// instant written in two time zones is equal. Floating times, dates and local values whose TZID
+// could not be resolved are not instants: they are equal only to a value of the same kind
+// and TZID with the same wall clock time.
+func (d DateTime) Equal(other DateTime) bool {
+	if d.isInstant() && other.isInstant() {
+		return d.Time.Equal(other.Time)
+	}
+	return d.Kind == other.Kind && d.TZID == other.TZID && d.Unresolved == other.Unresolved && d.Time.Equal(other.Time)
+}
+
+// isInstant reports whether the value denotes a single point in time.
+func (d DateTime) isInstant() bool {
+	return d.Kind == DateTimeUTC || (d.Kind == DateTimeLocal && !d.Unresolved)
+}
+
+// Equal reports whether two texts have the same decoded value, language and alternate representation.
+func (t Text) Equal(other Text) bool {
+	return equalText(t.Value, other.Value) &&
+		strings.EqualFold(t.Language, other.Language) &&
+		equalURL(t.AltRep, other.AltRep)
+}
+
+// Equal reports whether two positions are the same. Two nil positions are equal.
+func (g *Geo) Equal(other *Geo) bool {
+	if g == nil || other == nil {
+		return g == other
+	}
+	return *g == *other
+}
+
+// Equal reports whether two organizers have the same address and parameters.
+func (o *Organizer) Equal(other *Organizer) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+	return equalURL(o.CalAddress, other.CalAddress) &&
+		o.CommonName == other.CommonName &&
+		equalURL(o.Directory, other.Directory) &&
+		equalURL(o.SentBy, other.SentBy) &&
+		strings.EqualFold(o.Language, other.Language) &&
+		o.OtherParams.Equal(other.OtherParams)
+}
+
+// Equal reports whether two attendees have the same address and parameters.
+// Unset CUTYPE, ROLE and PARTSTAT parameters equal their defaults, INDIVIDUAL,
+// REQ-PARTICIPANT and NEEDS-ACTION.
+func (a Attendee) Equal(other Attendee) bool {
+	return equalURL(a.CalAddress, other.CalAddress) &&
+		a.CommonName == other.CommonName &&
+		equalEnum(a.CalendarUserType, other.CalendarUserType, CalendarUserTypeIndividual) &&
+		equalUnordered(a.Member, other.Member, equalURL) &&
+		equalEnum(a.Role, other.Role, AttendeeRoleRequired) &&
+		equalEnum(a.ParticipationStatus, other.ParticipationStatus, ParticipationStatusNeedsAction) &&
+		a.RSVP == other.RSVP &&
+		equalUnordered(a.DelegatedTo, other.DelegatedTo, equalURL) &&
+		equalUnordered(a.DelegatedFrom, other.DelegatedFrom, equalURL) &&
+		equalURL(a.SentBy, other.SentBy) &&
+		equalURL(a.Directory, other.Directory) &&
+		strings.EqualFold(a.Language, other.Language) &&
+		a.OtherParams.Equal(other.OtherParams)
+}
+
+// Equal reports whether two attachments reference the same URI or hold the same content.
+// Inline content that is not encoded the same way is compared decoded, so differences in base64 padding do not matter.
+func (a Attachment) Equal(other Attachment) bool {
+	if a.IsInline() != other.IsInline() ||
+		!equalURL(a.URI, other.URI) ||
+		!strings.EqualFold(a.FormatType, other.FormatType) ||
+		!a.OtherParams.Equal(other.OtherParams) {
+		return false
+	}
+	if !a.IsInline() || a.encoded == other.encoded {
+		return true
+	}
+	data, err := a.Bytes()
+	otherData, otherErr := other.Bytes()
+	return err == nil && otherErr == nil && bytes.Equal(data, otherData)
+}
+
+// Equal reports whether two request statuses have the same code, description, extra data and language.
+func (r RequestStatus) Equal(other RequestStatus) bool {
+	return slices.Equal(r.Code, other.Code) &&
+		equalText(r.Description, other.Description) &&
+		equalText(r.ExtraData, other.ExtraData) &&
+		strings.EqualFold(r.Language, other.Language)
+}
+
+// Equal reports whether two relations reference the same component with the same type.
+// An unset RELTYPE equals PARENT, its default.
+func (r Relation) Equal(other Relation) bool {
+	return r.UID == other.UID &&
+		equalEnum(r.Type, other.Type, RelationTypeParent) &&
+		r.OtherParams.Equal(other.OtherParams)
+}
+
+// equalProps reports whether two XProp or IANAProp maps hold the same properties.
+// The properties of a name may be in any order, and a nil map equals an empty one.
+func equalProps(a, b map[string][]Property) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, properties := range a {
+		otherProperties, ok := b[name]
+		if !ok || !equalUnordered(properties, otherProperties, Property.Equal) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalUnordered reports whether a and b hold equal elements in any order.
+// equal must be an equivalence relation.
+func equalUnordered[T any](a, b []T, equal func(T, T) bool) bool {
+	return matchAll(len(a), len(b), func(i, j int) bool { return equal(a[i], b[j]) })
+}
+
+// equalByKey reports whether a and b hold equal elements in any order, for element types whose Equal
+// method has a pointer receiver and that carry a key, such as a UID, that equal elements always share.
+// Only elements with the same key are compared, so the cost grows with the number of elements
+// rather than with its square.
+func equalByKey[T any, K comparable](a, b []T, key func(*T) K, equal func(*T, *T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	candidates := make(map[K][]int, len(b))
+	for j := range b {
+		k := key(&b[j])
+		candidates[k] = append(candidates[k], j)
+	}
+	for i := range a {
+		k := key(&a[i])
+		indexes := candidates[k]
+		match := slices.IndexFunc(indexes, func(j int) bool { return equal(&a[i], &b[j]) })
+		if match < 0 {
+			return false
+		}
+		candidates[k] = slices.Delete(indexes, match, match+1)
+	}
+	return true
+}
+
+// matchAll reports whether every one of the n elements of a slice can be paired with a distinct
+// element of a second slice of length m, using equal(i, j) to compare them.
+func matchAll(n, m int, equal func(i, j int) bool) bool {
+	if n != m {
+		return false
+	}
+	matched := make([]bool, m)
+	for i := range n {
+		found := false
+		for j := range m {
+			if !matched[j] && equal(i, j) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// equalText reports whether two TEXT values are the same once their escapes are decoded.
+func equalText(a, b string) bool {
+	return UnescapeText(a) == UnescapeText(b)
+}
+
+// equalString reports whether two strings are identical.
+func equalString(a, b string) bool {
+	return a == b
+}
+
+// equalURL reports whether two URIs are the same. Two nil URIs are equal.
+func equalURL(a, b *url.URL) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+// equalEnum reports whether two enumerated values are the same, ignoring case.
+// An empty value equals fallback, the default of the property or parameter.
+func equalEnum[T ~string](a, b, fallback T) bool {
+	return strings.EqualFold(string(defaultString(a, fallback)), string(defaultString(b, fallback)))
+}
+
+// defaultString returns value, or fallback if value is empty.
+func defaultString[T ~string](value, fallback T) T {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -5,7 +5,6 @@
 package model
 
 import (
-	"net/url"
 	"time"
 
 	"github.com/michael-gallo/simpleical/rrule"
@@ -18,7 +17,7 @@ type EventStatus string
 const (
 	EventStatusConfirmed EventStatus = "CONFIRMED"
 	EventStatusTentative EventStatus = "TENTATIVE"
-	EventStatusCancelled EventStatus = "CANCELED"
+	EventStatusCancelled EventStatus = "CANCELLED"
 )
 
 // EventTransp represents VEVENT TRANSP values. Note VTODO TRANSP values are different.
@@ -47,23 +46,33 @@ type Event struct {
 	// Start defines the date and time that the event begins. Refers to the DTSTART property.
 	// REQUIRED if no METHOD property. MUST NOT occur more than once
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.2.4
-	Start time.Time
+	Start DateTime
+
+	// Class defines the access classification for the event. Refers to the CLASS property.
+	// OPTIONAL, MUST NOT occur more than once.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.3.
+	Class Class
+
+	// Created is the date and time that the event was created in the calendar store. Refers to the CREATED property.
+	// OPTIONAL, MUST NOT occur more than once.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.7.1.
+	Created time.Time
 
 	// Summary is a short, one-line summary about the event. Refers to the SUMMARY property.
 	// OPTIONAL, MUST NOT occur more than once.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.12
-	Summary string
+	Summary Text
 
 	// Description is used to capture lengthy textual descriptions associated with the event. Refers to the DESCRIPTION property.
 	// OPTIONAL, MUST NOT occur more than once.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.5
-	Description string
+	Description Text
 
 	// Geo specifies the latitude and longitude of the activity specified by a calendar component.
 	// Refers to the GEO property. Can be specified in Events and Todos.
-	// Must be precise up to 6 decimal places.
+	// Must be precise up to 6 decimal places. Nil if the event has no GEO property.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.6.
-	Geo []float64
+	Geo *Geo
 
 	// LastModified specifies the date and time tthat the information associated with the calendar information was last revised.
 	// Refers to the LAST-MODIFIED property. Can be specified in Events, Todos, Journals, and TimeZones.
@@ -73,7 +82,7 @@ type Event struct {
 	// Location is the location where the event takes place. Refers to the LOCATION property.
 	// OPTIONAL, MUST NOT occur more than once.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.7.
-	Location string
+	Location Text
 
 	// Organizer is the organizer of the event. Refers to the ORGANIZER property.
 	// OPTIONAL, MUST NOT occur more than once.
@@ -111,7 +120,7 @@ type Event struct {
 	// RecurrenceID is the recurrence identifier for the event. Refers to the RECURRENCE-ID property.
 	// OPTIONAL, MUST NOT occur more than once.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.4.
-	RecurrenceID time.Time
+	RecurrenceID DateTime
 
 	// RRule is the recurrence rule for the event. Refers to the RRULE property.
 	// OPTIONAL, SHOULD NOT occur more than once.
@@ -122,7 +131,7 @@ type Event struct {
 	// dtend in the ICAL format.
 	// Can not be specified if a Duration is specified.
 	// See the datetime specification for more information: https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.5.
-	End time.Time
+	End DateTime
 
 	// The event's duration.
 	// Can not be specified if an End time is specified.
@@ -132,12 +141,11 @@ type Event struct {
 	// OPTIONAL, MAY occur more than once.
 	// Optional and can be defined multiple times.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.1.
-	// TODO: define a more robust type for attachments.
-	Attach []string
+	Attach []Attachment
 
 	// Attendee is used to represent an ATTENDEE component in the iCalendar format.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.1.
-	Attendees []url.URL
+	Attendees []Attendee
 
 	// Categories specifies the categories that the calendar component belongs to.
 	// Can be specified in Events, Todos, and Journals.
@@ -146,51 +154,58 @@ type Event struct {
 
 	// Comment specifies non-processing information intended to provide a comment to the calendar user.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.4.
-	Comment []string
+	Comment []Text
 
 	// Contact is used to represent contact information.
 	// Can be specified in Events, Todos, Journals, and FreeBusy Components.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.2.
-	Contacts []string
+	Contacts []Text
 
 	// Exception Date-Times, property name EXDATE.
 	// This is optional and repeatable.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.1.
-	ExceptionDates []time.Time
+	ExceptionDates []DateTime
 
-	// Property Name: REQUEST-STATUS Represented as RSTATUS.
+	// Property Name: REQUEST-STATUS.
 	// The status code returned for a scheduling request.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.3.
-	RequestStatus []string
+	RequestStatus []RequestStatus
 
 	// Property Name: RELATED-TO.
 	// Used to represent a relationship or reference between one calendar component and another.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.5.
-	Related []string
+	Related []Relation
 
 	// Property Name: RESOURCES.
 	// Defines equipment or resources anticipated for an event.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.10.
-	Resources []string
+	Resources []Text
 
 	// Recurrence Date-Times.
 	// This is optional and repeatable.
+	// An RDATE of periods, with VALUE=PERIOD, is kept in IANAProp instead.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.2.
-	Rdate []time.Time
+	Rdate []DateTime
 
 	// A Non-Standard Property. Can be represented by any name with a X-prefix.
 	// This is optional and repeatable.
-	// The keys of the map are expected to include the X-prefix.
+	// The keys of the map are the property names, including the X-prefix.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.2.
-	XProp map[string]string
+	XProp map[string][]Property
 
 	// An IANA registered property name.
 	// This is optional and repeatable.
-	// As of right now this is implemented as a map of string to string with no validation of whether the property is a real IANA registered property.
+	// Any property without a dedicated field is stored here, keyed by property name.
+	// There is no validation of whether the property is a real IANA registered property.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.1.
-	IANAProp map[string]string
+	IANAProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once.
 	// Sub-components: VALARM.
 	Alarms []Alarm
+
+	// OPTIONAL, MAY occur more than once.
+	// Components nested in this event that the model has no dedicated type for, eg: X- components.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.
+	OtherComponents []Component
 }
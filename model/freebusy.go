@@ -5,7 +5,6 @@
 package model
 
 import (
-	"net/url"
 	"time"
 )
 
@@ -38,7 +37,7 @@ type FreeBusy struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the contact information for the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.2
-	Contact string
+	Contact Text
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies when the calendar component begins.
@@ -63,12 +62,12 @@ type FreeBusy struct {
 	// OPTIONAL, MAY occur more than once
 	// Specifies the participants that are invited to the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.1
-	Attendees []url.URL
+	Attendees []Attendee
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies non-processing information intended to provide a comment to the calendar user.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.4
-	Comment []string
+	Comment []Text
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies one or more free or busy time intervals.
@@ -78,17 +77,22 @@ type FreeBusy struct {
 	// OPTIONAL, MAY occur more than once
 	// Specifies the status code returned for a scheduling request.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.3
-	RequestStatus []string
+	RequestStatus []RequestStatus
 
 	// OPTIONAL, MAY occur more than once
 	// A Non-Standard Property. Can be represented by any name with a X-prefix.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.2
-	XProp map[string]string
+	XProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once
 	// An IANA registered property name.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.1
-	IANAProp map[string]string
+	IANAProp map[string][]Property
+
+	// OPTIONAL, MAY occur more than once
+	// Components nested in this free/busy component that the model has no dedicated type for, eg: X- components.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+	OtherComponents []Component
 }
 
 // FreeBusyTime represents a single free/busy time interval with its status.
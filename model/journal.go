@@ -5,7 +5,6 @@
 package model
 
 import (
-	"net/url"
 	"time"
 
 	"github.com/michael-gallo/simpleical/rrule"
@@ -21,16 +20,6 @@ const (
 	JournalStatusCancelled JournalStatus = "CANCELLED"
 )
 
-// JournalClass represents the possible values for a VJOURNAL's CLASS field.
-// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.3
-type JournalClass string
-
-const (
-	JournalClassPublic       JournalClass = "PUBLIC"
-	JournalClassPrivate      JournalClass = "PRIVATE"
-	JournalClassConfidential JournalClass = "CONFIDENTIAL"
-)
-
 // Journal represents a VJOURNAL component in the iCalendar format.
 // A VJOURNAL is a grouping of component properties that describe a journal entry.
 // Does not take up time on a calendar.
@@ -49,7 +38,7 @@ type Journal struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// Access Classification for the calendar component.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.3
-	Class JournalClass
+	Class Class
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the date and time that the calendar information was created.
@@ -59,7 +48,7 @@ type Journal struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies when the calendar component begins.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.2.4
-	DTStart time.Time
+	DTStart DateTime
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the date and time that the information associated with the calendar component was last revised.
@@ -74,7 +63,7 @@ type Journal struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the revision sequence number of the calendar component within a sequence of revisions.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.7.4
-	RecurrenceID time.Time
+	RecurrenceID DateTime
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the revision sequence number of the calendar component within a sequence of revisions.
@@ -89,7 +78,7 @@ type Journal struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// A short, one-line summary about the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.12
-	Summary string
+	Summary Text
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies a URL associated with the activity.
@@ -103,12 +92,12 @@ type Journal struct {
 	// OPTIONAL, MAY occur more than once
 	// Provides the capability to associate a document object with a calendar component.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.1
-	Attach []string
+	Attach []Attachment
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the participants that are invited to the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.1
-	Attendees []url.URL
+	Attendees []Attendee
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the categories that the calendar component belongs to.
@@ -118,49 +107,55 @@ type Journal struct {
 	// OPTIONAL, MAY occur more than once
 	// Specifies non-processing information intended to provide a comment to the calendar user.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.4
-	Comment []string
+	Comment []Text
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the contact information for the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.2
-	Contacts []string
+	Contacts []Text
 
 	// OPTIONAL, MAY occur more than once
 	// Used to capture lengthy textual descriptions associated with the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.5
-	Description []string
+	Description []Text
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the list of date/time exceptions for a recurring calendar component.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.1
-	ExceptionDates []time.Time
+	ExceptionDates []DateTime
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies a relationship or reference between one calendar component and another.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.5
-	Related []string
+	Related []Relation
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the list of date/time values for recurring activities.
+	// An RDATE of periods, with VALUE=PERIOD, is kept in IANAProp instead.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.2
-	Rdate []time.Time
+	Rdate []DateTime
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the status code returned for a scheduling request.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.3
-	RequestStatus []string
+	RequestStatus []RequestStatus
 
 	// OPTIONAL, MAY occur more than once
 	// A Non-Standard Property. Can be represented by any name with a X-prefix.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.2
-	XProp map[string]string
+	XProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once
 	// An IANA registered property name.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.1
-	IANAProp map[string]string
+	IANAProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once
 	// Sub-components: VALARM
 	Alarms []Alarm
+
+	// OPTIONAL, MAY occur more than once
+	// Components nested in this journal that the model has no dedicated type for, eg: X- components.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+	OtherComponents []Component
 }
@@ -0,0 +1,179 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"slices"
+)
+
+// RelationType represents the RELTYPE parameter of a RELATED-TO property.
+// Values other than the ones below are experimental or IANA registered types.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.15
+// See: https://datatracker.ietf.org/doc/html/rfc9253#section-5
+type RelationType string
+
+const (
+	RelationTypeParent  RelationType = "PARENT"
+	RelationTypeChild   RelationType = "CHILD"
+	RelationTypeSibling RelationType = "SIBLING"
+
+	// The types below are defined by RFC 9253.
+	RelationTypeSnapshot       RelationType = "SNAPSHOT"
+	RelationTypeDependsOn      RelationType = "DEPENDS-ON"
+	RelationTypeRefID          RelationType = "REFID"
+	RelationTypeConcept        RelationType = "CONCEPT"
+	RelationTypeFinishToStart  RelationType = "FINISHTOSTART"
+	RelationTypeFinishToFinish RelationType = "FINISHTOFINISH"
+	RelationTypeStartToFinish  RelationType = "STARTTOFINISH"
+	RelationTypeStartToStart   RelationType = "STARTTOSTART"
+	RelationTypeFirst          RelationType = "FIRST"
+	RelationTypeNext           RelationType = "NEXT"
+)
+
+// Relation represents a RELATED-TO property, a reference from one calendar component to another.
+// eg: RELATED-TO;RELTYPE=PARENT:19960401-080045-4000F192713@example.com
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.5
+type Relation struct {
+	// UID is the UID of the related component.
+	UID string
+
+	// denoted by RELTYPE, PARENT if empty
+	// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.15
+	Type RelationType
+
+	// Parameters other than RELTYPE, eg: the GAP parameter of RFC 9253 or X- parameters.
+	OtherParams Parameters
+}
+
+// Hierarchy is the graph the RELATED-TO properties of the events, to-dos and journals of a calendar form.
+// Every map is keyed by UID and lists UIDs in sorted order.
+// A relation is recorded from both of its ends, so a PARENT relation on one component and a
+// CHILD relation on the other describe the same edge.
+type Hierarchy struct {
+	// Parents maps a component to its parents.
+	Parents map[string][]string
+
+	// Children maps a component to its children.
+	Children map[string][]string
+
+	// Siblings maps a component to its siblings.
+	Siblings map[string][]string
+
+	// DependsOn maps a component to the components it depends on, from the DEPENDS-ON relation
+	// and the FINISHTOSTART, FINISHTOFINISH, STARTTOFINISH and STARTTOSTART relations of RFC 9253.
+	DependsOn map[string][]string
+
+	// Dangling holds the relations that refer to a UID no component of the calendar has.
+	Dangling []DanglingRelation
+
+	// Cycles holds the cycles of the parent and dependency graphs, each as the UIDs along the cycle,
+	// eg: []string{"a", "b"} when a is the parent of b and b is the parent of a.
+	Cycles [][]string
+}
+
+// DanglingRelation is a relation to a component that is not in the calendar.
+type DanglingRelation struct {
+	// UID is the UID of the component the relation belongs to.
+	UID string
+
+	// Relation is the relation itself.
+	Relation Relation
+}
+
+// BuildHierarchy builds the graph of the relations between the events, to-dos and journals of the calendar.
+// Relations of types that describe neither a hierarchy nor a dependency, such as SNAPSHOT or NEXT,
+// are only checked for dangling references.
+func (c *Calendar) BuildHierarchy() Hierarchy {
+	hierarchy := Hierarchy{
+		Parents:   map[string][]string{},
+		Children:  map[string][]string{},
+		Siblings:  map[string][]string{},
+		DependsOn: map[string][]string{},
+	}
+
+	uids := make(map[string]struct{}, len(c.Events)+len(c.Todos)+len(c.Journals))
+	var references []DanglingRelation
+	for component := range c.Components() {
+		uid := component.GetUID()
+		uids[uid] = struct{}{}
+		for _, relation := range component.GetRelated() {
+			references = append(references, DanglingRelation{UID: uid, Relation: relation})
+		}
+	}
+
+	for _, reference := range references {
+		from, to := reference.UID, reference.Relation.UID
+		if _, ok := uids[to]; !ok {
+			hierarchy.Dangling = append(hierarchy.Dangling, reference)
+			continue
+		}
+		switch reference.Relation.Type {
+		case "", RelationTypeParent:
+			addEdge(hierarchy.Parents, from, to)
+			addEdge(hierarchy.Children, to, from)
+		case RelationTypeChild:
+			addEdge(hierarchy.Children, from, to)
+			addEdge(hierarchy.Parents, to, from)
+		case RelationTypeSibling:
+			addEdge(hierarchy.Siblings, from, to)
+			addEdge(hierarchy.Siblings, to, from)
+		case RelationTypeDependsOn, RelationTypeFinishToStart, RelationTypeFinishToFinish, RelationTypeStartToFinish, RelationTypeStartToStart:
+			addEdge(hierarchy.DependsOn, from, to)
+		}
+	}
+
+	hierarchy.Cycles = append(findCycles(hierarchy.Parents), findCycles(hierarchy.DependsOn)...)
+	return hierarchy
+}
+
+// addEdge adds to to the sorted list of from in graph, unless it is already there.
+func addEdge(graph map[string][]string, from, to string) {
+	edges := graph[from]
+	index, found := slices.BinarySearch(edges, to)
+	if !found {
+		graph[from] = slices.Insert(edges, index, to)
+	}
+}
+
+// findCycles returns the cycles of a directed graph, visiting the nodes in sorted order so the result is stable.
+func findCycles(graph map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	var cycles [][]string
+	state := make(map[string]int, len(graph))
+	var path []string
+
+	var visit func(node string)
+	visit = func(node string) {
+		state[node] = inProgress
+		path = append(path, node)
+		for _, next := range graph[node] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case inProgress:
+				start := slices.Index(path, next)
+				cycles = append(cycles, slices.Clone(path[start:]))
+			}
+		}
+		path = path[:len(path)-1]
+		state[node] = done
+	}
+
+	nodes := make([]string, 0, len(graph))
+	for node := range graph {
+		nodes = append(nodes, node)
+	}
+	slices.Sort(nodes)
+	for _, node := range nodes {
+		if state[node] == unvisited {
+			visit(node)
+		}
+	}
+	return cycles
+}
@@ -5,12 +5,66 @@
 package model
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
 	"time"
 
 	"github.com/michael-gallo/simpleical/rrule"
 )
 
+// ErrInvalidUTCOffset is returned by ParseUTCOffset for a value that is not a valid UTC-OFFSET.
+var ErrInvalidUTCOffset = errors.New("invalid UTC offset")
+
+// UTCOffset is a UTC-OFFSET value in seconds east of UTC, as used by TZOFFSETFROM and TZOFFSETTO.
+// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.14
+type UTCOffset int
+
+// ParseUTCOffset parses a UTC-OFFSET value of the form +HHMM or +HHMMSS, eg: -0500 or +013045.
+// A negative zero offset, -0000, is not allowed.
+func ParseUTCOffset(value string) (UTCOffset, error) {
+	if (len(value) != 5 && len(value) != 7) || (value[0] != '+' && value[0] != '-') {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidUTCOffset, value)
+	}
+	digits := [3]int{}
+	for i := 1; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidUTCOffset, value)
+		}
+		digits[(i-1)/2] = digits[(i-1)/2]*10 + int(value[i]-'0')
+	}
+	hours, minutes, seconds := digits[0], digits[1], digits[2]
+	if minutes > 59 || seconds > 59 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidUTCOffset, value)
+	}
+	offset := UTCOffset(hours*3600 + minutes*60 + seconds)
+	if value[0] == '-' {
+		if offset == 0 {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidUTCOffset, value)
+		}
+		offset = -offset
+	}
+	return offset, nil
+}
+
+// Duration returns the offset as a time.Duration.
+func (o UTCOffset) Duration() time.Duration {
+	return time.Duration(o) * time.Second
+}
+
+// String formats the offset as a UTC-OFFSET value, with seconds only if they are not zero.
+func (o UTCOffset) String() string {
+	sign := '+'
+	if o < 0 {
+		sign, o = '-', -o
+	}
+	hours, minutes, seconds := o/3600, o/60%60, o%60
+	if seconds != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, hours, minutes)
+}
+
 // TimeZone represents a VTIMEZONE component in the iCalendar format.
 // A grouping of component properties that defines a time zone.
 // https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.5
@@ -44,12 +98,17 @@ type TimeZone struct {
 	// OPTIONAL, MAY occur more than once
 	// A Non-Standard Property. Can be represented by any name with a X-prefix.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.2
-	XProp map[string]string
+	XProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once
 	// An IANA registered property name.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.1
-	IANAProp map[string]string
+	IANAProp map[string][]Property
+
+	// OPTIONAL, MAY occur more than once
+	// Components nested in this time zone that the model has no dedicated type for, eg: X- components.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+	OtherComponents []Component
 }
 
 // TimeZoneProperty is defined in the spec as tzprop and describes the fields that are used to represent either a standard or daylight sub-component in a timezone.
@@ -57,12 +116,12 @@ type TimeZoneProperty struct {
 	// REQUIRED, MUST NOT occur more than once
 	// The time zone offset from UTC when daylight saving time is in effect.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.3.3
-	TimeZoneOffsetFrom string
+	TimeZoneOffsetFrom UTCOffset
 
 	// REQUIRED, MUST NOT occur more than once
 	// The time zone offset from UTC when standard time is in effect.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.3.4
-	TimeZoneOffsetTo string
+	TimeZoneOffsetTo UTCOffset
 
 	// REQUIRED, MUST NOT occur more than once
 	// Date-Time Start, used to specify when the calendar event starts
@@ -72,7 +131,7 @@ type TimeZoneProperty struct {
 	// OPTIONAL, MAY occur more than once
 	// A comment to describe the Time Zone Property
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.4
-	Comment []string
+	Comment []Text
 
 	// OPTIONAL, MAY occur more than once
 	// Recurrence Date-Times
@@ -90,10 +149,15 @@ type TimeZoneProperty struct {
 	// OPTIONAL, MAY occur more than once
 	// A Non-Standard Property. Can be represented by any name with a X-prefix.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.2
-	XProp map[string]string
+	XProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once
 	// An IANA registered property name.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.1
-	IANAProp map[string]string
+	IANAProp map[string][]Property
+
+	// OPTIONAL, MAY occur more than once
+	// Components nested in this observance that the model has no dedicated type for, eg: X- components.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+	OtherComponents []Component
 }
@@ -5,7 +5,6 @@
 package model
 
 import (
-	"net/url"
 	"time"
 )
 
@@ -20,16 +19,6 @@ const (
 	TodoStatusCancelled   TodoStatus = "CANCELLED"
 )
 
-// TodoClass represents the possible values for a VTODO's CLASS field.
-// See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.3
-type TodoClass string
-
-const (
-	TodoClassPublic       TodoClass = "PUBLIC"
-	TodoClassPrivate      TodoClass = "PRIVATE"
-	TodoClassConfidential TodoClass = "CONFIDENTIAL"
-)
-
 // TodoTransp represents the possible values for a VTODO's TRANSP field.
 // See: https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.2.7
 type TodoTransp string
@@ -56,7 +45,7 @@ type Todo struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// Access Classification for the calendar component.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.3
-	Class TodoClass
+	Class Class
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the date and time that a to-do was actually completed.
@@ -71,18 +60,18 @@ type Todo struct {
 	// OPTIONAL, MAY occur more than once
 	// Used to capture lengthy textual descriptions associated with the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.5
-	Description []string
+	Description []Text
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies when the calendar component begins.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.2.4
-	DTStart time.Time
+	DTStart DateTime
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the date and time that a to-do is expected to be completed.
 	// Either DUE or DURATION may be specified in a VTODO, but not both.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.2.3
-	Due time.Time
+	Due DateTime
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies a positive duration of time.
@@ -92,8 +81,9 @@ type Todo struct {
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Geo specifies the latitude and longitude of the activity specified by a calendar component.
+	// Nil if the to-do has no GEO property.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.6
-	Geo []float64
+	Geo *Geo
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the date and time that the information associated with the calendar component was last revised.
@@ -103,7 +93,7 @@ type Todo struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// The location where the activity takes place.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.7
-	Location string
+	Location Text
 
 	// OPTIONAL, MUST NOT occur more than once
 	// The organizer of the activity.
@@ -123,7 +113,7 @@ type Todo struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the revision sequence number of the calendar component within a sequence of revisions.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.7.4
-	RecurrenceID time.Time
+	RecurrenceID DateTime
 
 	// OPTIONAL, MUST NOT occur more than once
 	// Specifies the revision sequence number of the calendar component within a sequence of revisions.
@@ -138,7 +128,7 @@ type Todo struct {
 	// OPTIONAL, MUST NOT occur more than once
 	// A short, one-line summary about the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.12
-	Summary string
+	Summary Text
 
 	// OPTIONAL, MUST NOT occur more than once
 	// The time transparency for the activity.
@@ -157,12 +147,12 @@ type Todo struct {
 	// OPTIONAL, MAY occur more than once
 	// Provides the capability to associate a document object with a calendar component.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.1
-	Attach []string
+	Attach []Attachment
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the participants that are invited to the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.1
-	Attendees []url.URL
+	Attendees []Attendee
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the categories that the calendar component belongs to.
@@ -172,49 +162,55 @@ type Todo struct {
 	// OPTIONAL, MAY occur more than once
 	// Specifies non-processing information intended to provide a comment to the calendar user.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.4
-	Comment []string
+	Comment []Text
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the contact information for the activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.2
-	Contacts []string
+	Contacts []Text
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the list of date/time exceptions for a recurring calendar component.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.1
-	ExceptionDates []time.Time
+	ExceptionDates []DateTime
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the status code returned for a scheduling request.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.3
-	RequestStatus []string
+	RequestStatus []RequestStatus
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies a relationship or reference between one calendar component and another.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.5
-	Related []string
+	Related []Relation
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies equipment or resources anticipated for an activity.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.10
-	Resources []string
+	Resources []Text
 
 	// OPTIONAL, MAY occur more than once
 	// Specifies the list of date/time values for recurring activities.
+	// An RDATE of periods, with VALUE=PERIOD, is kept in IANAProp instead.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.2
-	Rdate []time.Time
+	Rdate []DateTime
 
 	// OPTIONAL, MAY occur more than once
 	// A Non-Standard Property. Can be represented by any name with a X-prefix.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.2
-	XProp map[string]string
+	XProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once
 	// An IANA registered property name.
 	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.1
-	IANAProp map[string]string
+	IANAProp map[string][]Property
 
 	// OPTIONAL, MAY occur more than once
 	// Sub-components: VALARM
 	Alarms []Alarm
+
+	// OPTIONAL, MAY occur more than once
+	// Components nested in this todo that the model has no dedicated type for, eg: X- components.
+	// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+	OtherComponents []Component
 }
@@ -25,24 +25,36 @@ const (
 type EventToken string
 
 const (
-	EventTokenSummary      EventToken = "SUMMARY"
-	EventTokenDescription  EventToken = "DESCRIPTION"
-	EventTokenLocation     EventToken = "LOCATION"
-	EventTokenOrganizer    EventToken = "ORGANIZER"
-	EventTokenStatus       EventToken = "STATUS"
-	EventTokenSequence     EventToken = "SEQUENCE"
-	EventTokenTransp       EventToken = "TRANSP"
-	EventTokenDtstart      EventToken = "DTSTART"
-	EventTokenDtend        EventToken = "DTEND"
-	EventTokenUID          EventToken = "UID"
-	EventTokenDTStamp      EventToken = "DTSTAMP"
-	EventTokenContact      EventToken = "CONTACT"
-	EventTokenLastModified EventToken = "LAST-MODIFIED"
-	EventTokenComment      EventToken = "COMMENT"
-	EventTokenCategories   EventToken = "CATEGORIES"
-	EventTokenDuration     EventToken = "DURATION"
-	EventTokenGeo          EventToken = "GEO"
-	EventTokenRRule        EventToken = "RRULE"
+	EventTokenSummary        EventToken = "SUMMARY"
+	EventTokenDescription    EventToken = "DESCRIPTION"
+	EventTokenLocation       EventToken = "LOCATION"
+	EventTokenOrganizer      EventToken = "ORGANIZER"
+	EventTokenStatus         EventToken = "STATUS"
+	EventTokenSequence       EventToken = "SEQUENCE"
+	EventTokenTransp         EventToken = "TRANSP"
+	EventTokenDtstart        EventToken = "DTSTART"
+	EventTokenDtend          EventToken = "DTEND"
+	EventTokenUID            EventToken = "UID"
+	EventTokenDTStamp        EventToken = "DTSTAMP"
+	EventTokenContact        EventToken = "CONTACT"
+	EventTokenLastModified   EventToken = "LAST-MODIFIED"
+	EventTokenComment        EventToken = "COMMENT"
+	EventTokenCategories     EventToken = "CATEGORIES"
+	EventTokenDuration       EventToken = "DURATION"
+	EventTokenGeo            EventToken = "GEO"
+	EventTokenRRule          EventToken = "RRULE"
+	EventTokenClass          EventToken = "CLASS"
+	EventTokenCreated        EventToken = "CREATED"
+	EventTokenPriority       EventToken = "PRIORITY"
+	EventTokenURL            EventToken = "URL"
+	EventTokenRecurrenceID   EventToken = "RECURRENCE-ID"
+	EventTokenAttach         EventToken = "ATTACH"
+	EventTokenAttendee       EventToken = "ATTENDEE"
+	EventTokenExceptionDates EventToken = "EXDATE"
+	EventTokenRequestStatus  EventToken = "REQUEST-STATUS"
+	EventTokenRelated        EventToken = "RELATED-TO"
+	EventTokenResources      EventToken = "RESOURCES"
+	EventTokenRdate          EventToken = "RDATE"
 )
 
 // TodoToken represents the names of the properties in a VTODO
@@ -77,8 +89,8 @@ const (
 	TodoTokenComment         TodoToken = "COMMENT"
 	TodoTokenContact         TodoToken = "CONTACT"
 	TodoTokenExceptionDates  TodoToken = "EXDATE"
-	TodoTokenRequestStatus   TodoToken = "RSTATUS"
-	TodoTokenRelated         TodoToken = "RELATED"
+	TodoTokenRequestStatus   TodoToken = "REQUEST-STATUS"
+	TodoTokenRelated         TodoToken = "RELATED-TO"
 	TodoTokenResources       TodoToken = "RESOURCES"
 	TodoTokenRdate           TodoToken = "RDATE"
 )
@@ -107,9 +119,9 @@ const (
 	JournalTokenContact        JournalToken = "CONTACT"
 	JournalTokenDescription    JournalToken = "DESCRIPTION"
 	JournalTokenExceptionDates JournalToken = "EXDATE"
-	JournalTokenRelated        JournalToken = "RELATED"
+	JournalTokenRelated        JournalToken = "RELATED-TO"
 	JournalTokenRdate          JournalToken = "RDATE"
-	JournalTokenRequestStatus  JournalToken = "RSTATUS"
+	JournalTokenRequestStatus  JournalToken = "REQUEST-STATUS"
 )
 
 // FreeBusyToken represents the names of the properties in a VFREEBUSY
@@ -127,7 +139,7 @@ const (
 	FreeBusyTokenAttendee      FreeBusyToken = "ATTENDEE"
 	FreeBusyTokenComment       FreeBusyToken = "COMMENT"
 	FreeBusyTokenFreeBusy      FreeBusyToken = "FREEBUSY"
-	FreeBusyTokenRequestStatus FreeBusyToken = "RSTATUS"
+	FreeBusyTokenRequestStatus FreeBusyToken = "REQUEST-STATUS"
 )
 
 // TimezoneToken represents the names of the properties in a VTIMEZONE
@@ -144,6 +156,7 @@ const (
 	TimezoneTokenComment            TimezoneToken = "COMMENT"
 	TimezoneTokenRdate              TimezoneToken = "RDATE"
 	TimezoneTokenTimeZoneName       TimezoneToken = "TZNAME"
+	TimezoneTokenRRule              TimezoneToken = "RRULE"
 )
 
 // AlarmToken represents the names of the properties in a VALARM
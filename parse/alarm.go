@@ -2,40 +2,55 @@ package parse
 
 import (
 	"fmt"
-	"net/url"
+	"strings"
 
+	"github.com/michael-gallo/simpleical/icaldur"
 	"github.com/michael-gallo/simpleical/model"
 )
 
 const alarmLocation = "Alarm"
 
+// alarmActions are the ACTION values RFC 5545 defines for a VALARM.
+var alarmActions = []model.AlarmAction{model.AlarmActionAudio, model.AlarmActionDisplay, model.AlarmActionEmail}
+
 // parseAlarmProperty parses a single property line and adds it to the provided alarm.
-func parseAlarmProperty(propertyName string, value string, params map[string]string, alarm *model.Alarm) error {
+func (p *parser) parseAlarmProperty(propertyName string, value string, params model.Parameters, alarm *model.Alarm) error {
 	switch model.AlarmToken(propertyName) {
 	case model.AlarmTokenAction:
-		return setOnceProperty(&alarm.Action, model.AlarmAction(value), propertyName, alarmLocation)
+		action, err := enumValue(&p.options, value, propertyName, alarmActions, true)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&alarm.Action, action, propertyName, alarmLocation)
 	case model.AlarmTokenTrigger:
-		return setOnceProperty(&alarm.Trigger, value, propertyName, alarmLocation)
+		trigger, err := parseTrigger(value, params)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&alarm.Trigger, trigger, propertyName, alarmLocation)
 	case model.AlarmTokenAttach:
-		alarm.Attach = append(alarm.Attach, value)
+		attachment, err := parseAttachment(value, params)
+		if err != nil {
+			return err
+		}
+		alarm.Attach = append(alarm.Attach, attachment)
 		return nil
 	case model.AlarmTokenDuration:
 		return setOnceDurationProperty(&alarm.Duration, value, propertyName, alarmLocation)
 	case model.AlarmTokenDescription:
-		alarm.Description = append(alarm.Description, value)
-		return nil
+		return p.appendTextProperty(&alarm.Description, value, params, propertyName)
 	case model.AlarmTokenRepeat:
-		return setOnceIntProperty(&alarm.Repeat, value, propertyName, alarmLocation)
+		return p.setOnceIntProperty(&alarm.Repeat, value, propertyName, alarmLocation)
 	case model.AlarmTokenSummary:
-		return setOnceProperty(&alarm.Summary, value, propertyName, alarmLocation)
+		return p.setOnceTextProperty(&alarm.Summary, value, params, propertyName, alarmLocation)
 	case model.AlarmTokenAttendee:
-		parsedURL, err := url.Parse(value)
+		attendee, err := p.parseAttendee(value, params)
 		if err != nil {
 			return err
 		}
-		alarm.Attendees = append(alarm.Attendees, *parsedURL)
+		alarm.Attendees = append(alarm.Attendees, attendee)
 	default:
-		return fmt.Errorf("%w: %s", errInvalidAlarmProperty, propertyName)
+		return p.appendExtraProperty(&alarm.XProp, &alarm.IANAProp, propertyName, value, params, ErrInvalidAlarmProperty)
 	}
 	return nil
 }
@@ -43,29 +58,67 @@ func parseAlarmProperty(propertyName string, value string, params map[string]str
 // validateAlarm ensures that all required values are present for an alarm.
 func validateAlarm(alarm *model.Alarm) error {
 	if alarm.Action == "" {
-		return errMissingAlarmActionProperty
+		return ErrMissingAlarmActionProperty
 	}
-	if alarm.Trigger == "" {
-		return errMissingAlarmTriggerProperty
+	if alarm.Trigger.IsZero() {
+		return ErrMissingAlarmTriggerProperty
 	}
 
 	// Validate action-specific requirements
 	switch alarm.Action {
 	case model.AlarmActionDisplay:
 		if len(alarm.Description) == 0 {
-			return errMissingAlarmDescriptionForDisplay
+			return ErrMissingAlarmDescriptionForDisplay
 		}
 	case model.AlarmActionEmail:
 		if len(alarm.Description) == 0 {
-			return errMissingAlarmDescriptionForEmail
+			return ErrMissingAlarmDescriptionForEmail
 		}
-		if alarm.Summary == "" {
-			return errMissingAlarmSummaryForEmail
+		if alarm.Summary.Value == "" {
+			return ErrMissingAlarmSummaryForEmail
 		}
 		if len(alarm.Attendees) == 0 {
-			return errMissingAlarmAttendeesForEmail
+			return ErrMissingAlarmAttendeesForEmail
 		}
 	}
 
 	return nil
 }
+
+// parseTrigger parses the value of a TRIGGER property.
+// A relative trigger is a DURATION with an optional RELATED parameter, an absolute trigger
+// is a DATE-TIME that must be in UTC.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.6.3
+func parseTrigger(value string, params model.Parameters) (model.Trigger, error) {
+	related := params.Get("RELATED")
+	switch valueType := params.Get("VALUE"); {
+	case strings.EqualFold(valueType, "DATE-TIME"):
+		if related != "" {
+			return model.Trigger{}, fmt.Errorf("%w: TRIGGER RELATED is not allowed with an absolute trigger", ErrInvalidPropertyValue)
+		}
+		if !strings.HasSuffix(value, "Z") {
+			return model.Trigger{}, fmt.Errorf("%w: TRIGGER %s must be in UTC", ErrInvalidPropertyValue, value)
+		}
+		triggerTime, err := icaldur.ParseIcalTime(value)
+		if err != nil {
+			return model.Trigger{}, fmt.Errorf("%w: TRIGGER %s", ErrInvalidPropertyValue, value)
+		}
+		return model.Trigger{Time: triggerTime}, nil
+	case valueType == "" || strings.EqualFold(valueType, "DURATION"):
+		trigger := model.Trigger{Related: model.TriggerRelatedStart}
+		if related != "" {
+			trigger.Related = model.TriggerRelation(upperASCII(related))
+			if trigger.Related != model.TriggerRelatedStart && trigger.Related != model.TriggerRelatedEnd {
+				return model.Trigger{}, fmt.Errorf("%w: TRIGGER RELATED %s", ErrInvalidPropertyValue, related)
+			}
+		}
+		duration, err := icaldur.ParseICalDuration(value)
+		if err != nil {
+			return model.Trigger{}, fmt.Errorf("%w: TRIGGER %s", ErrInvalidPropertyValue, value)
+		}
+		trigger.Duration = duration
+		return trigger, nil
+	default:
+		return model.Trigger{}, fmt.Errorf("%w: TRIGGER VALUE %s", ErrInvalidPropertyValue, valueType)
+	}
+}
@@ -0,0 +1,55 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/michael-gallo/simpleical/model"
+)
+
+// parseAttachment parses a calendar line starting with ATTACH.
+// An inline attachment is validated by decoding it without keeping the result, so large documents are not copied.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.1
+func parseAttachment(value string, params model.Parameters) (model.Attachment, error) {
+	var formatType, encoding, valueType string
+	var otherParams model.Parameters
+	for paramName, paramValues := range params {
+		switch paramName {
+		case "FMTTYPE":
+			formatType = paramValues[0]
+		case "ENCODING":
+			encoding = paramValues[0]
+		case "VALUE":
+			valueType = paramValues[0]
+		default:
+			if otherParams == nil {
+				otherParams = make(model.Parameters)
+			}
+			otherParams[paramName] = paramValues
+		}
+	}
+
+	if encoding == "" && (valueType == "" || strings.EqualFold(valueType, "URI")) {
+		uri, err := parseURI(value, "ATTACH")
+		if err != nil {
+			return model.Attachment{}, err
+		}
+		return model.Attachment{URI: uri, FormatType: formatType, OtherParams: otherParams}, nil
+	}
+
+	if !strings.EqualFold(encoding, "BASE64") || !strings.EqualFold(valueType, "BINARY") {
+		return model.Attachment{}, fmt.Errorf("%w: ATTACH with ENCODING %s and VALUE %s, inline attachments must use ENCODING=BASE64 and VALUE=BINARY", ErrInvalidPropertyValue, encoding, valueType)
+	}
+	if _, err := io.Copy(io.Discard, base64.NewDecoder(base64.StdEncoding, strings.NewReader(value))); err != nil {
+		return model.Attachment{}, fmt.Errorf("%w: ATTACH %v", ErrInvalidPropertyValue, err)
+	}
+	attachment := model.NewBase64Attachment(value, formatType)
+	attachment.OtherParams = otherParams
+	return attachment, nil
+}
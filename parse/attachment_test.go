@@ -0,0 +1,73 @@
+package parse
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAttachment(t *testing.T) {
+	testCases := []struct {
+		name               string
+		value              string
+		params             model.Parameters
+		expectedAttachment model.Attachment
+		expectedError      error
+	}{
+		{
+			name:               "URI",
+			value:              "ftp://example.com/pub/reports/r-960812.ps",
+			params:             model.Parameters{"FMTTYPE": {"application/postscript"}},
+			expectedAttachment: model.Attachment{URI: &url.URL{Scheme: "ftp", Host: "example.com", Path: "/pub/reports/r-960812.ps"}, FormatType: "application/postscript"},
+		},
+		{
+			name:               "Explicit URI value type",
+			value:              "CID:jsmith.part3.960817T083000.xyzMail@example.com",
+			params:             model.Parameters{"VALUE": {"uri"}},
+			expectedAttachment: model.Attachment{URI: &url.URL{Scheme: "cid", Opaque: "jsmith.part3.960817T083000.xyzMail@example.com"}},
+		},
+		{
+			name:               "Inline with lower-case parameter values",
+			value:              "AAEC",
+			params:             model.Parameters{"ENCODING": {"base64"}, "VALUE": {"binary"}},
+			expectedAttachment: model.NewInlineAttachment([]byte{0, 1, 2}, ""),
+		},
+		{
+			name:          "Inline without VALUE=BINARY",
+			value:         "AAEC",
+			params:        model.Parameters{"ENCODING": {"BASE64"}},
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Binary with an encoding other than base64",
+			value:         "AAEC",
+			params:        model.Parameters{"ENCODING": {"8BIT"}, "VALUE": {"BINARY"}},
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Invalid base64",
+			value:         "AA*C",
+			params:        model.Parameters{"ENCODING": {"BASE64"}, "VALUE": {"BINARY"}},
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Invalid URI",
+			value:         "%zz",
+			expectedError: ErrInvalidPropertyValue,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			attachment, err := parseAttachment(testCase.value, testCase.params)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedAttachment, attachment)
+		})
+	}
+}
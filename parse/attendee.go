@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/michael-gallo/simpleical/model"
+)
+
+// Values RFC 5545 defines for the enumerated parameters of an ATTENDEE. All of them can be extended.
+var (
+	attendeeRoles         = []model.AttendeeRole{model.AttendeeRoleChair, model.AttendeeRoleRequired, model.AttendeeRoleOptional, model.AttendeeRoleNonParticipant}
+	participationStatuses = []model.ParticipationStatus{model.ParticipationStatusNeedsAction, model.ParticipationStatusAccepted, model.ParticipationStatusDeclined, model.ParticipationStatusTentative, model.ParticipationStatusDelegated, model.ParticipationStatusCompleted, model.ParticipationStatusInProcess}
+	calendarUserTypes     = []model.CalendarUserType{model.CalendarUserTypeIndividual, model.CalendarUserTypeGroup, model.CalendarUserTypeResource, model.CalendarUserTypeRoom, model.CalendarUserTypeUnknown}
+)
+
+// parseAttendee parses a calendar line starting with ATTENDEE.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.1
+func (p *parser) parseAttendee(value string, params model.Parameters) (model.Attendee, error) {
+	attendee := model.Attendee{}
+	for paramName, paramValues := range params {
+		paramValue := paramValues[0]
+		var err error
+		switch paramName {
+		case "CN":
+			attendee.CommonName = paramValue
+		case "CUTYPE":
+			attendee.CalendarUserType, err = enumValue(&p.options, paramValue, paramName, calendarUserTypes, true)
+		case "MEMBER":
+			attendee.Member, err = parseCalAddresses(paramValues, paramName)
+		case "ROLE":
+			attendee.Role, err = enumValue(&p.options, paramValue, paramName, attendeeRoles, true)
+		case "PARTSTAT":
+			attendee.ParticipationStatus, err = enumValue(&p.options, paramValue, paramName, participationStatuses, true)
+		case "RSVP":
+			attendee.RSVP, err = parseBoolean(paramValue, paramName)
+		case "DELEGATED-TO":
+			attendee.DelegatedTo, err = parseCalAddresses(paramValues, paramName)
+		case "DELEGATED-FROM":
+			attendee.DelegatedFrom, err = parseCalAddresses(paramValues, paramName)
+		case "SENT-BY":
+			attendee.SentBy, err = parseURI(paramValue, "ATTENDEE SENT-BY")
+		case "DIR":
+			attendee.Directory, err = parseURI(paramValue, "ATTENDEE DIR")
+		case "LANGUAGE":
+			attendee.Language = paramValue
+		default:
+			if attendee.OtherParams == nil {
+				attendee.OtherParams = make(model.Parameters)
+			}
+			attendee.OtherParams[paramName] = paramValues
+		}
+		if err != nil {
+			return model.Attendee{}, err
+		}
+	}
+
+	parsedURI, err := parseURI(value, "ATTENDEE")
+	if err != nil {
+		return model.Attendee{}, err
+	}
+	attendee.CalAddress = parsedURI
+
+	return attendee, nil
+}
+
+// parseCalAddresses parses the values of a parameter holding a list of calendar user addresses, such as MEMBER.
+func parseCalAddresses(values []string, paramName string) ([]*url.URL, error) {
+	addresses := make([]*url.URL, 0, len(values))
+	for _, value := range values {
+		address, err := parseURI(value, "ATTENDEE "+paramName)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// parseBoolean parses a BOOLEAN value, TRUE or FALSE in any case.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.2
+func parseBoolean(value, name string) (bool, error) {
+	switch {
+	case strings.EqualFold(value, "TRUE"):
+		return true, nil
+	case strings.EqualFold(value, "FALSE"):
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %s %s", ErrInvalidPropertyValue, name, value)
+	}
+}
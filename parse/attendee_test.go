@@ -0,0 +1,88 @@
+package parse
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAttendee(t *testing.T) {
+	testCases := []struct {
+		name             string
+		value            string
+		params           model.Parameters
+		strictEnums      bool
+		expectedAttendee model.Attendee
+		expectedError    error
+	}{
+		{
+			name:             "Attendee without parameters",
+			value:            "MAILTO:dev1@example.com",
+			expectedAttendee: model.Attendee{CalAddress: &url.URL{Scheme: "mailto", Opaque: "dev1@example.com"}},
+		},
+		{
+			name:   "Enumerated parameters are upper-cased",
+			value:  "mailto:dev1@example.com",
+			params: model.Parameters{"ROLE": {"opt-participant"}, "PARTSTAT": {"In-Process"}, "CUTYPE": {"group"}, "RSVP": {"True"}},
+			expectedAttendee: model.Attendee{
+				CalAddress:          &url.URL{Scheme: "mailto", Opaque: "dev1@example.com"},
+				CalendarUserType:    model.CalendarUserTypeGroup,
+				Role:                model.AttendeeRoleOptional,
+				ParticipationStatus: model.ParticipationStatusInProcess,
+				RSVP:                true,
+			},
+		},
+		{
+			name:             "Unknown role is kept by default",
+			value:            "mailto:dev1@example.com",
+			params:           model.Parameters{"ROLE": {"OBSERVER"}},
+			expectedAttendee: model.Attendee{CalAddress: &url.URL{Scheme: "mailto", Opaque: "dev1@example.com"}, Role: "OBSERVER"},
+		},
+		{
+			name:          "Unknown role is rejected with strict enums",
+			value:         "mailto:dev1@example.com",
+			params:        model.Parameters{"ROLE": {"OBSERVER"}},
+			strictEnums:   true,
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:             "X- participation status is accepted with strict enums",
+			value:            "mailto:dev1@example.com",
+			params:           model.Parameters{"PARTSTAT": {"X-WAITLISTED"}},
+			strictEnums:      true,
+			expectedAttendee: model.Attendee{CalAddress: &url.URL{Scheme: "mailto", Opaque: "dev1@example.com"}, ParticipationStatus: "X-WAITLISTED"},
+		},
+		{
+			name:          "Invalid RSVP",
+			value:         "mailto:dev1@example.com",
+			params:        model.Parameters{"RSVP": {"YES"}},
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Invalid address",
+			value:         "%zz",
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Invalid MEMBER address",
+			value:         "mailto:dev1@example.com",
+			params:        model.Parameters{"MEMBER": {"mailto:team@example.com", "%zz"}},
+			expectedError: ErrInvalidPropertyValue,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			p := &parser{options: options{strictEnums: testCase.strictEnums}}
+			attendee, err := p.parseAttendee(testCase.value, testCase.params)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedAttendee, attendee)
+		})
+	}
+}
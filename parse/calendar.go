@@ -3,26 +3,27 @@ package parse
 import "github.com/michael-gallo/simpleical/model"
 
 // parseCalendarProperty parses a single property line and sets its value in the provided vcalendar.
-func parseCalendarProperty(propertyName string, value string, _ map[string]string, calendar *model.Calendar) error {
+func (p *parser) parseCalendarProperty(propertyName string, value string, params model.Parameters, calendar *model.Calendar) error {
 	switch propertyName {
 	case "VERSION":
 		return setOnceProperty(&calendar.Version, value, propertyName, "VCALENDAR")
 	case "PRODID":
-		return setOnceProperty(&calendar.ProdID, value, propertyName, "VCALENDAR")
+		return setOnceProperty(&calendar.ProdID, p.text(value), propertyName, "VCALENDAR")
 	case "CALSCALE":
 		return setOnceProperty(&calendar.CalScale, value, propertyName, "VCALENDAR")
 	case "METHOD":
 		return setOnceProperty(&calendar.Method, value, propertyName, "VCALENDAR")
+	default:
+		return p.appendExtraProperty(&calendar.XProp, &calendar.IANAProp, propertyName, value, params, ErrInvalidCalendarProperty)
 	}
-	return nil
 }
 
 func validateCalendar(calendar *model.Calendar) error {
 	if calendar.Version == "" {
-		return errMissingCalendarVersionProperty
+		return ErrMissingCalendarVersionProperty
 	}
 	if calendar.ProdID == "" {
-		return errMissingCalendarProdIDProperty
+		return ErrMissingCalendarProdIDProperty
 	}
 	return nil
 }
@@ -0,0 +1,389 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/michael-gallo/simpleical/model"
+)
+
+// componentKind identifies the type of a component on the parser stack.
+type componentKind uint8
+
+const (
+	kindCalendar componentKind = iota
+	kindEvent
+	kindTodo
+	kindJournal
+	kindFreeBusy
+	kindTimeZone
+	kindStandard
+	kindDaylight
+	kindAlarm
+	// kindOther is any component without a dedicated model type, see model.Component.
+	kindOther
+	// kindSkipped is a component that lenient mode drops, together with everything inside it.
+	kindSkipped
+)
+
+// frame is a component that has been opened by a BEGIN line and not yet closed by its END line.
+type frame struct {
+	kind componentKind
+	// name is the component name from the BEGIN line, which the END line must repeat.
+	name string
+	// index counts the components named name that came before this one in the same parent.
+	index int
+	// properties counts the property lines of this component, see Limits.MaxPropertiesPerComponent.
+	properties int
+	// setProperties lists the set-once INTEGER and UTC-OFFSET properties read so far,
+	// as their zero value cannot tell whether they were set.
+	setProperties []string
+	// children counts the typed components opened so far directly inside this one, by kind.
+	// Components dropped in lenient mode are counted too, so that indexes match the input.
+	children [kindOther]int
+}
+
+// kindOfComponent returns the kind of a component with a dedicated model type.
+func kindOfComponent(name string) (componentKind, bool) {
+	switch model.SectionToken(name) {
+	case model.SectionTokenVCalendar:
+		return kindCalendar, true
+	case model.SectionTokenVEvent:
+		return kindEvent, true
+	case model.SectionTokenVTodo:
+		return kindTodo, true
+	case model.SectionTokenVJournal:
+		return kindJournal, true
+	case model.SectionTokenVFreebusy:
+		return kindFreeBusy, true
+	case model.SectionTokenVTimezone:
+		return kindTimeZone, true
+	case model.SectionTokenVStandard:
+		return kindStandard, true
+	case model.SectionTokenVDaylight:
+		return kindDaylight, true
+	case model.SectionTokenVAlarm:
+		return kindAlarm, true
+	default:
+		return kindOther, false
+	}
+}
+
+// canContain reports whether a typed component of kind child may be nested directly inside parent.
+// Generic components may appear anywhere and are not checked here.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6
+func canContain(parent, child componentKind) bool {
+	switch parent {
+	case kindCalendar:
+		return child == kindEvent || child == kindTodo || child == kindJournal || child == kindFreeBusy || child == kindTimeZone
+	case kindEvent, kindTodo:
+		return child == kindAlarm
+	case kindJournal:
+		// VJOURNAL alarms are not part of RFC 5545, but model.Journal keeps them, so they are accepted.
+		return child == kindAlarm
+	case kindTimeZone:
+		return child == kindStandard || child == kindDaylight
+	default:
+		return false
+	}
+}
+
+// push opens a component.
+func (p *parser) push(kind componentKind, name string, index int) {
+	p.stack = append(p.stack, frame{kind: kind, name: name, index: index})
+}
+
+// path describes the open components, eg: VCALENDAR/VEVENT[3]/VALARM[0].
+func (p *parser) path() string {
+	var builder strings.Builder
+	for i, open := range p.stack {
+		if i > 0 {
+			builder.WriteByte('/')
+		}
+		builder.WriteString(open.name)
+		// The VCALENDAR is only indexed when a stream may hold more than one.
+		if open.kind != kindCalendar || p.multiple {
+			builder.WriteByte('[')
+			builder.WriteString(strconv.Itoa(open.index))
+			builder.WriteByte(']')
+		}
+	}
+	return builder.String()
+}
+
+// handleBeginBlock opens the component named on a BEGIN line inside the innermost open component.
+func (p *parser) handleBeginBlock(name string) error {
+	parent := p.stack[len(p.stack)-1]
+	kind, known := kindOfComponent(name)
+
+	if parent.kind == kindSkipped {
+		p.push(kindSkipped, name, 0)
+		return nil
+	}
+
+	// Unknown components, and everything nested in them, are kept as generic components.
+	if !known || parent.kind == kindOther {
+		if !isValidPropertyName(name) {
+			return p.skipComponent(name, fmt.Errorf("%w: %s", ErrTemplateInvalidStartBlock, name))
+		}
+		index := 0
+		for _, sibling := range *p.otherComponentsOfParent() {
+			if sibling.Name == name {
+				index++
+			}
+		}
+		p.others = append(p.others, model.Component{Name: name})
+		p.push(kindOther, name, index)
+		return nil
+	}
+
+	if !canContain(parent.kind, kind) {
+		return p.skipComponent(name, fmt.Errorf("%w: %s inside %s", ErrInvalidComponentNesting, name, parent.name))
+	}
+
+	switch kind {
+	case kindEvent:
+		p.event = model.Event{}
+	case kindTodo:
+		p.todo = model.Todo{}
+	case kindJournal:
+		p.journal = model.Journal{}
+	case kindFreeBusy:
+		p.freeBusy = model.FreeBusy{}
+	case kindTimeZone:
+		p.timeZone = model.TimeZone{}
+	case kindStandard, kindDaylight:
+		p.observance = model.TimeZoneProperty{}
+	case kindAlarm:
+		p.alarm = model.Alarm{}
+	}
+	counts := &p.stack[len(p.stack)-1].children
+	index := counts[kind]
+	counts[kind]++
+	p.push(kind, name, index)
+	return nil
+}
+
+// skipComponent reports a component that cannot be opened, and in lenient mode skips it up to its END line.
+func (p *parser) skipComponent(name string, err error) error {
+	if err := p.report(SeverityError, "", err); err != nil {
+		return err
+	}
+	p.push(kindSkipped, name, 0)
+	return nil
+}
+
+// handleEndBlock closes the innermost open component, which must have the name given on the END line.
+// In lenient mode a stray END line is ignored, and an END line for an outer component first closes the ones inside it.
+func (p *parser) handleEndBlock(name string) error {
+	current := p.stack[len(p.stack)-1]
+	if current.name != name {
+		if err := p.report(SeverityWarning, "", fmt.Errorf("%w: END:%s while %s is open", ErrTemplateInvalidEndBlock, name, current.name)); err != nil {
+			return err
+		}
+		open := len(p.stack) - 1
+		for open >= 0 && p.stack[open].name != name {
+			open--
+		}
+		if open < 0 {
+			return nil
+		}
+		for len(p.stack)-1 > open {
+			// Closing never fails in lenient mode.
+			_ = p.closeComponent()
+		}
+	}
+	return p.closeComponent()
+}
+
+// closeComponent validates the innermost open component and attaches it to its parent.
+// In lenient mode a component that fails validation is dropped, except for the VCALENDAR itself, which is kept.
+func (p *parser) closeComponent() error {
+	current := p.stack[len(p.stack)-1]
+	if current.kind == kindCalendar {
+		if err := p.resolveLaterTimeZones(); err != nil {
+			return err
+		}
+	}
+	// The component stays on the stack while it is validated, so that errors point at it.
+	if err := p.validateComponent(current.kind); err != nil {
+		severity := SeverityError
+		if current.kind == kindCalendar {
+			severity = SeverityWarning
+		}
+		if err := p.report(severity, "", err); err != nil {
+			return err
+		}
+		if current.kind != kindCalendar {
+			p.stack = p.stack[:len(p.stack)-1]
+			return nil
+		}
+	}
+	p.stack = p.stack[:len(p.stack)-1]
+
+	switch current.kind {
+	case kindEvent:
+		p.calendar.Events = append(p.calendar.Events, p.event)
+	case kindTodo:
+		p.calendar.Todos = append(p.calendar.Todos, p.todo)
+	case kindJournal:
+		p.calendar.Journals = append(p.calendar.Journals, p.journal)
+	case kindFreeBusy:
+		p.calendar.FreeBusys = append(p.calendar.FreeBusys, p.freeBusy)
+	case kindTimeZone:
+		p.calendar.TimeZones = append(p.calendar.TimeZones, p.timeZone)
+		if p.timeZones == nil {
+			p.timeZones = make(map[string]model.TimeZone, 1)
+		}
+		p.timeZones[p.timeZone.TimeZoneID] = p.timeZone
+	case kindStandard:
+		p.timeZone.Standard = append(p.timeZone.Standard, p.observance)
+	case kindDaylight:
+		p.timeZone.Daylight = append(p.timeZone.Daylight, p.observance)
+	case kindAlarm:
+		alarms := p.alarmsOfParent()
+		*alarms = append(*alarms, p.alarm)
+	case kindOther:
+		last := len(p.others) - 1
+		component := p.others[last]
+		p.others[last] = model.Component{}
+		p.others = p.others[:last]
+		siblings := p.otherComponentsOfParent()
+		*siblings = append(*siblings, component)
+	}
+	if p.emit != nil && len(p.stack) == 1 {
+		p.emitTopLevel(current.kind)
+	}
+	return nil
+}
+
+// emitTopLevel hands the top-level component that was just attached to the calendar to p.emit,
+// and removes it from the calendar again, so that the calendar never holds more than one component.
+func (p *parser) emitTopLevel(kind componentKind) {
+	switch kind {
+	case kindEvent:
+		p.emit(takeLast(&p.calendar.Events))
+	case kindTodo:
+		p.emit(takeLast(&p.calendar.Todos))
+	case kindJournal:
+		p.emit(takeLast(&p.calendar.Journals))
+	case kindFreeBusy:
+		p.emit(takeLast(&p.calendar.FreeBusys))
+	case kindTimeZone:
+		p.emit(takeLast(&p.calendar.TimeZones))
+	case kindOther:
+		p.emit(takeLast(&p.calendar.OtherComponents))
+	}
+}
+
+// takeLast removes the last element of a slice and returns a copy of it.
+func takeLast[T any](slice *[]T) *T {
+	last := len(*slice) - 1
+	element := (*slice)[last]
+	var zero T
+	(*slice)[last] = zero
+	*slice = (*slice)[:last]
+	return &element
+}
+
+// validateComponent checks that the component of the given kind being closed has all of its required properties.
+func (p *parser) validateComponent(kind componentKind) error {
+	switch kind {
+	case kindCalendar:
+		return validateCalendar(p.calendar)
+	case kindEvent:
+		if err := validateEvent(&p.event); err != nil {
+			return err
+		}
+		return p.checkDTStamp(p.event.DTStamp, ErrMissingEventDTStampProperty)
+	case kindTodo:
+		if err := validateTodo(&p.todo); err != nil {
+			return err
+		}
+		return p.checkDTStamp(p.todo.DTStamp, ErrMissingTodoDTStampProperty)
+	case kindJournal:
+		if err := validateJournal(&p.journal); err != nil {
+			return err
+		}
+		return p.checkDTStamp(p.journal.DTStamp, ErrMissingJournalDTStampProperty)
+	case kindFreeBusy:
+		if err := validateFreeBusy(&p.freeBusy); err != nil {
+			return err
+		}
+		return p.checkDTStamp(p.freeBusy.DTStamp, ErrMissingFreeBusyDTStampProperty)
+	case kindTimeZone:
+		return validateTimeZone(&p.timeZone)
+	case kindStandard, kindDaylight:
+		return validateObservance(p.stack[len(p.stack)-1].setProperties)
+	case kindAlarm:
+		return validateAlarm(&p.alarm)
+	default:
+		return nil
+	}
+}
+
+// checkDTStamp returns missingErr if a DTSTAMP is required but was not set.
+func (p *parser) checkDTStamp(dtStamp time.Time, missingErr error) error {
+	if p.options.requireDTStamp && dtStamp.IsZero() {
+		return missingErr
+	}
+	return nil
+}
+
+// alarmsOfParent returns the Alarms slice of the component that contains the alarm being closed.
+func (p *parser) alarmsOfParent() *[]model.Alarm {
+	switch p.stack[len(p.stack)-1].kind {
+	case kindTodo:
+		return &p.todo.Alarms
+	case kindJournal:
+		return &p.journal.Alarms
+	default: // kindEvent
+		return &p.event.Alarms
+	}
+}
+
+// otherComponentsOfParent returns the OtherComponents slice of the component that contains the generic component being closed.
+func (p *parser) otherComponentsOfParent() *[]model.Component {
+	switch p.stack[len(p.stack)-1].kind {
+	case kindEvent:
+		return &p.event.OtherComponents
+	case kindTodo:
+		return &p.todo.OtherComponents
+	case kindJournal:
+		return &p.journal.OtherComponents
+	case kindFreeBusy:
+		return &p.freeBusy.OtherComponents
+	case kindTimeZone:
+		return &p.timeZone.OtherComponents
+	case kindStandard, kindDaylight:
+		return &p.observance.OtherComponents
+	case kindAlarm:
+		return &p.alarm.OtherComponents
+	case kindOther:
+		return &p.others[len(p.others)-1].Components
+	default: // kindCalendar
+		return &p.calendar.OtherComponents
+	}
+}
+
+// parseOtherComponentProperty adds a property to the innermost generic component.
+func (p *parser) parseOtherComponentProperty(propertyName string, value string, params model.Parameters) error {
+	if !isValidPropertyName(propertyName) {
+		return fmt.Errorf("%w: %s", ErrInvalidPropertyLine, propertyName)
+	}
+	current := &p.others[len(p.others)-1]
+	// The parameter map is reused between lines, so it must be copied before it is kept.
+	current.Properties = append(current.Properties, model.Property{
+		Name:   propertyName,
+		Params: maps.Clone(params),
+		Value:  value,
+	})
+	return nil
+}
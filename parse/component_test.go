@@ -0,0 +1,67 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponentNesting(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedError error
+	}{
+		{
+			name:          "STANDARD outside of a VTIMEZONE",
+			input:         "BEGIN:VCALENDAR\nBEGIN:STANDARD\nEND:STANDARD\nEND:VCALENDAR\n",
+			expectedError: ErrInvalidComponentNesting,
+		},
+		{
+			name:          "VALARM directly inside the VCALENDAR",
+			input:         "BEGIN:VCALENDAR\nBEGIN:VALARM\nEND:VALARM\nEND:VCALENDAR\n",
+			expectedError: ErrInvalidComponentNesting,
+		},
+		{
+			name:          "VTODO inside a VEVENT",
+			input:         "BEGIN:VCALENDAR\nBEGIN:VEVENT\nBEGIN:VTODO\nEND:VTODO\nEND:VEVENT\nEND:VCALENDAR\n",
+			expectedError: ErrInvalidComponentNesting,
+		},
+		{
+			name:          "END:VALARM without BEGIN:VALARM",
+			input:         "BEGIN:VCALENDAR\nEND:VALARM\nEND:VCALENDAR\n",
+			expectedError: ErrTemplateInvalidEndBlock,
+		},
+		{
+			name:          "END:VEVENT without BEGIN:VEVENT",
+			input:         "BEGIN:VCALENDAR\nEND:VEVENT\nEND:VCALENDAR\n",
+			expectedError: ErrTemplateInvalidEndBlock,
+		},
+		{
+			name:          "END:VCALENDAR while a VEVENT is open",
+			input:         "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n",
+			expectedError: ErrTemplateInvalidEndBlock,
+		},
+		{
+			name:          "BEGIN after END:VCALENDAR",
+			input:         "BEGIN:VCALENDAR\nVERSION:2.0\nPRODID:-//Test//EN\nEND:VCALENDAR\nBEGIN:VEVENT\n",
+			expectedError: ErrContentAfterEndBlock,
+		},
+		{
+			name:          "Input ends while a VEVENT is open",
+			input:         "BEGIN:VCALENDAR\nBEGIN:VEVENT\n",
+			expectedError: ErrInvalidCalendarFormatMissingEnd,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, err := IcalString(tc.input)
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, calendar)
+		})
+	}
+}
@@ -0,0 +1,326 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/michael-gallo/simpleical/icaldur"
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/rrule"
+)
+
+// weekdays maps the weekdays of a recurrence rule to their time.Weekday.
+var weekdays = map[rrule.Weekday]time.Weekday{
+	rrule.WeekdaySunday:    time.Sunday,
+	rrule.WeekdayMonday:    time.Monday,
+	rrule.WeekdayTuesday:   time.Tuesday,
+	rrule.WeekdayWednesday: time.Wednesday,
+	rrule.WeekdayThursday:  time.Thursday,
+	rrule.WeekdayFriday:    time.Friday,
+	rrule.WeekdaySaturday:  time.Saturday,
+}
+
+// parseDateTime parses the value of a property that can hold a DATE or a DATE-TIME, such as DTSTART.
+// A VALUE=DATE parameter, or an eight digit value without a VALUE parameter, makes it a DATE.
+// Otherwise a value ending in Z is in UTC, a value with a TZID parameter is placed in that time zone,
+// and any other value is a floating time.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.5
+func (p *parser) parseDateTime(value string, params model.Parameters, propertyName, componentType string) (model.DateTime, error) {
+	valueType := params.Get("VALUE")
+	if strings.EqualFold(valueType, "DATE") || (valueType == "" && len(value) == 8) {
+		date, err := icaldur.ParseIcalDate(value)
+		if err != nil {
+			return model.DateTime{}, fmt.Errorf("%w: %s property %s in iCal", ErrParseErrorInComponent, componentType, propertyName)
+		}
+		return model.DateTime{Time: date, Kind: model.DateTimeDate}, nil
+	}
+
+	wall, err := icaldur.ParseIcalTime(value)
+	if err != nil {
+		return model.DateTime{}, fmt.Errorf("%w: %s property %s in iCal", ErrParseErrorInComponent, componentType, propertyName)
+	}
+	// A TZID parameter must not be applied to a UTC value, so it is ignored.
+	if value[len(value)-1] == 'Z' {
+		return model.DateTime{Time: wall, Kind: model.DateTimeUTC}, nil
+	}
+	tzid := params.Get("TZID")
+	if tzid == "" {
+		return model.DateTime{Time: wall, Kind: model.DateTimeFloating}, nil
+	}
+	local, resolved, err := p.localTime(wall, tzid)
+	return model.DateTime{Time: local, Kind: model.DateTimeLocal, TZID: tzid, Unresolved: !resolved}, err
+}
+
+// localTime places the wall clock time of a DATE-TIME with a TZID parameter in that time zone.
+// When the whole calendar is being built, a TZID that is not known yet may still be defined by a VTIMEZONE
+// further down, so the wall clock time is kept in UTC and resolved once the calendar ends, see resolveLaterTimeZones.
+// The Decoder hands components out as soon as they end, so there the time zone must be known already.
+// It reports false if the TZID is not known yet, in which case the wall clock time is returned in UTC.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.19
+func (p *parser) localTime(wall time.Time, tzid string) (time.Time, bool, error) {
+	if local, ok := p.resolveTZID(wall, tzid); ok {
+		return local, true, nil
+	}
+	if p.emit == nil {
+		if p.unresolvedTZIDs == nil {
+			p.unresolvedTZIDs = make(map[string]*ParseError, 1)
+		}
+		if _, ok := p.unresolvedTZIDs[tzid]; !ok {
+			p.unresolvedTZIDs[tzid] = p.locate("")
+		}
+		return wall, false, nil
+	}
+	return wall, false, p.reportUnknownTimeZone(p.locate(""), tzid)
+}
+
+// reportUnknownTimeZone handles a TZID that is neither defined by a VTIMEZONE nor in the IANA time zone database.
+// Such values keep their wall clock time, see model.DateTime.Unresolved, as many producers use vendor TZIDs.
+// The problem is reported as a warning in lenient mode, and only fails a strict parse with WithRequireKnownTimeZones.
+func (p *parser) reportUnknownTimeZone(parseError *ParseError, tzid string) error {
+	if !p.lenient && !p.options.requireKnownTimeZones {
+		return nil
+	}
+	parseError.Severity = SeverityWarning
+	parseError.Err = fmt.Errorf("%w: %s", ErrUnknownTimeZone, tzid)
+	return p.record(parseError)
+}
+
+// resolveTZID places a wall clock time in the time zone a TZID refers to.
+// A VTIMEZONE of the calendar takes precedence over the IANA time zone database.
+// It reports false if the TZID is unknown.
+func (p *parser) resolveTZID(wall time.Time, tzid string) (time.Time, bool) {
+	if timeZone, ok := p.timeZones[tzid]; ok {
+		if offset, ok := timeZoneOffset(&timeZone, wall); ok {
+			return inLocation(wall, time.FixedZone(tzid, offset)), true
+		}
+	}
+
+	location, ok := p.locations[tzid]
+	if !ok {
+		// Loading a location reads the time zone database, so the result is cached, even when it failed.
+		// A leading slash marks a globally unique TZID, which is otherwise an IANA name.
+		name := strings.TrimPrefix(tzid, "/")
+		if name != "" && name != "Local" {
+			location, _ = time.LoadLocation(name)
+		}
+		if p.locations == nil {
+			p.locations = make(map[string]*time.Location, 1)
+		}
+		p.locations[tzid] = location
+	}
+	if location == nil {
+		return wall, false
+	}
+	return inLocation(wall, location), true
+}
+
+// resolveLaterTimeZones places the DATE-TIME values whose TZID was not known when they were read
+// in their time zone, now that every VTIMEZONE of the calendar has been read.
+// A TZID that is still unknown is reported at the property that first used it, see reportUnknownTimeZone.
+func (p *parser) resolveLaterTimeZones() error {
+	if len(p.unresolvedTZIDs) == 0 {
+		return nil
+	}
+	resolve := func(dateTime *model.DateTime) {
+		if dateTime.Kind != model.DateTimeLocal || !dateTime.Unresolved {
+			return
+		}
+		if local, ok := p.resolveTZID(dateTime.Time, dateTime.TZID); ok {
+			dateTime.Time = local
+			dateTime.Unresolved = false
+		}
+	}
+	resolveAll := func(dateTimes []model.DateTime) {
+		for i := range dateTimes {
+			resolve(&dateTimes[i])
+		}
+	}
+	for i := range p.calendar.Events {
+		event := &p.calendar.Events[i]
+		resolve(&event.Start)
+		resolve(&event.End)
+		resolve(&event.RecurrenceID)
+		resolveAll(event.ExceptionDates)
+		resolveAll(event.Rdate)
+	}
+	for i := range p.calendar.Todos {
+		todo := &p.calendar.Todos[i]
+		resolve(&todo.DTStart)
+		resolve(&todo.Due)
+		resolve(&todo.RecurrenceID)
+		resolveAll(todo.ExceptionDates)
+		resolveAll(todo.Rdate)
+	}
+	for i := range p.calendar.Journals {
+		journal := &p.calendar.Journals[i]
+		resolve(&journal.DTStart)
+		resolve(&journal.RecurrenceID)
+		resolveAll(journal.ExceptionDates)
+		resolveAll(journal.Rdate)
+	}
+
+	for _, tzid := range slices.Sorted(maps.Keys(p.unresolvedTZIDs)) {
+		_, defined := p.timeZones[tzid]
+		if defined || p.locations[tzid] != nil {
+			continue
+		}
+		if err := p.reportUnknownTimeZone(p.unresolvedTZIDs[tzid], tzid); err != nil {
+			return err
+		}
+	}
+	p.unresolvedTZIDs = nil
+	return nil
+}
+
+// inLocation returns the same wall clock time in another location.
+func inLocation(wall time.Time, location *time.Location) time.Time {
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), location)
+}
+
+// timeZoneOffset returns the UTC offset in seconds that a VTIMEZONE defines for a wall clock time.
+// The STANDARD or DAYLIGHT observance with the latest onset at or before the wall clock time applies.
+// Before the first onset, the TZOFFSETFROM of the earliest observance applies.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.5
+func timeZoneOffset(timeZone *model.TimeZone, wall time.Time) (int, bool) {
+	var (
+		current, first   *model.TimeZoneProperty
+		latest, earliest time.Time
+	)
+	for _, observances := range [][]model.TimeZoneProperty{timeZone.Standard, timeZone.Daylight} {
+		for i := range observances {
+			observance := &observances[i]
+			if first == nil || observance.DTStart.Before(earliest) {
+				first, earliest = observance, observance.DTStart
+			}
+			if onset, ok := latestOnset(observance, wall); ok && (current == nil || onset.After(latest)) {
+				current, latest = observance, onset
+			}
+		}
+	}
+
+	switch {
+	case current != nil:
+		return int(current.TimeZoneOffsetTo), true
+	case first != nil:
+		return int(first.TimeZoneOffsetFrom), true
+	default:
+		return 0, false
+	}
+}
+
+// latestOnset returns the last time an observance started at or before a wall clock time.
+// Onsets come from DTSTART, RDATE and a yearly RRULE, the form every producer uses for time zone transitions.
+// It reports false if the observance had not started yet.
+func latestOnset(observance *model.TimeZoneProperty, wall time.Time) (time.Time, bool) {
+	start := observance.DTStart
+	if start.IsZero() || start.After(wall) {
+		return time.Time{}, false
+	}
+	latest := start
+	for _, rdate := range observance.Rdate {
+		if rdate.After(latest) && !rdate.After(wall) {
+			latest = rdate
+		}
+	}
+
+	rule := observance.RRule
+	if rule == nil || rule.Frequency != rrule.FrequencyYearly {
+		return latest, true
+	}
+	// The onset of this year may still be ahead, in which case the one of the previous year applies.
+	for year := wall.Year(); year >= wall.Year()-1 && year >= start.Year(); year-- {
+		onset, ok := yearlyOnset(rule, start, year)
+		if !ok || onset.Before(start) || onset.After(wall) {
+			continue
+		}
+		if rule.Until != nil && onset.After(*rule.Until) {
+			continue
+		}
+		if rule.Count != nil && year-start.Year() >= *rule.Count {
+			continue
+		}
+		if onset.After(latest) {
+			latest = onset
+		}
+		break
+	}
+	return latest, true
+}
+
+// yearlyOnset returns the occurrence of a yearly RRULE in the given year, at the time of day of start,
+// eg: BYMONTH=3;BYDAY=2SU is the second Sunday of March.
+func yearlyOnset(rule *rrule.RRule, start time.Time, year int) (time.Time, bool) {
+	month := start.Month()
+	if len(rule.Month) > 0 {
+		month = time.Month(rule.Month[0])
+	}
+	day := start.Day()
+	switch {
+	case len(rule.Weekday) > 0:
+		weekday, ok := weekdays[rule.Weekday[0].Weekday]
+		if !ok {
+			return time.Time{}, false
+		}
+		if len(rule.Monthday) > 0 {
+			// Older producers write the second Sunday as BYDAY=SU;BYMONTHDAY=8,9,10,11,12,13,14.
+			day, ok = monthdayOnWeekday(year, month, weekday, rule.Monthday)
+		} else {
+			day, ok = nthWeekday(year, month, weekday, rule.Weekday[0].Interval)
+		}
+		if !ok {
+			return time.Time{}, false
+		}
+	case len(rule.Monthday) > 0:
+		day = rule.Monthday[0]
+		if day < 0 {
+			day += daysIn(year, month) + 1
+		}
+	}
+	if day < 1 || day > daysIn(year, month) {
+		return time.Time{}, false
+	}
+	return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, time.UTC), true
+}
+
+// nthWeekday returns the day of the month of the nth weekday of a month, counting from the end if n is negative.
+func nthWeekday(year int, month time.Month, weekday time.Weekday, n int) (int, bool) {
+	days := daysIn(year, month)
+	var day int
+	switch {
+	case n > 0:
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()
+		day = 1 + (int(weekday)-int(first)+7)%7 + (n-1)*7
+	case n < 0:
+		last := time.Date(year, month, days, 0, 0, 0, 0, time.UTC).Weekday()
+		day = days - (int(last)-int(weekday)+7)%7 + (n+1)*7
+	default:
+		return 0, false
+	}
+	return day, day >= 1 && day <= days
+}
+
+// monthdayOnWeekday returns the first of the given days of the month that falls on the weekday.
+func monthdayOnWeekday(year int, month time.Month, weekday time.Weekday, monthdays []int) (int, bool) {
+	days := daysIn(year, month)
+	for _, day := range monthdays {
+		if day < 0 {
+			day += days + 1
+		}
+		if day >= 1 && day <= days && time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() == weekday {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// daysIn returns the number of days in a month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,103 @@
+package parse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/rrule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNthWeekday(t *testing.T) {
+	testCases := []struct {
+		name       string
+		month      time.Month
+		n          int
+		expected   int
+		expectedOK bool
+	}{
+		{name: "Second Sunday of March", month: time.March, n: 2, expected: 9, expectedOK: true},
+		{name: "First Sunday of November", month: time.November, n: 1, expected: 2, expectedOK: true},
+		{name: "Last Sunday of October", month: time.October, n: -1, expected: 26, expectedOK: true},
+		{name: "Fifth Sunday of February does not exist", month: time.February, n: 5},
+		{name: "Zero is not an ordinal", month: time.March, n: 0},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			day, ok := nthWeekday(2025, testCase.month, time.Sunday, testCase.n)
+			assert.Equal(t, testCase.expectedOK, ok)
+			if testCase.expectedOK {
+				assert.Equal(t, testCase.expected, day)
+			}
+		})
+	}
+}
+
+func TestTimeZoneOffset(t *testing.T) {
+	// Central European Time as written by most producers, and US Eastern Time in the older BYMONTHDAY form.
+	europe := &model.TimeZone{
+		TimeZoneID: "Europe/Berlin",
+		Standard: []model.TimeZoneProperty{{
+			TimeZoneOffsetFrom: 2 * 60 * 60,
+			TimeZoneOffsetTo:   60 * 60,
+			DTStart:            time.Date(1996, time.October, 27, 3, 0, 0, 0, time.UTC),
+			RRule:              &rrule.RRule{Frequency: rrule.FrequencyYearly, Weekday: []rrule.ByDay{{Weekday: rrule.WeekdaySunday, Interval: -1}}, Month: []int{10}},
+		}},
+		Daylight: []model.TimeZoneProperty{{
+			TimeZoneOffsetFrom: 60 * 60,
+			TimeZoneOffsetTo:   2 * 60 * 60,
+			DTStart:            time.Date(1981, time.March, 29, 2, 0, 0, 0, time.UTC),
+			RRule:              &rrule.RRule{Frequency: rrule.FrequencyYearly, Weekday: []rrule.ByDay{{Weekday: rrule.WeekdaySunday, Interval: -1}}, Month: []int{3}},
+		}},
+	}
+	newYork := &model.TimeZone{
+		TimeZoneID: "America/New_York",
+		Standard: []model.TimeZoneProperty{{
+			TimeZoneOffsetFrom: -4 * 60 * 60,
+			TimeZoneOffsetTo:   -5 * 60 * 60,
+			DTStart:            time.Date(1970, time.October, 25, 2, 0, 0, 0, time.UTC),
+			RRule:              &rrule.RRule{Frequency: rrule.FrequencyYearly, Weekday: []rrule.ByDay{{Weekday: rrule.WeekdaySunday, Interval: 1}}, Monthday: []int{1, 2, 3, 4, 5, 6, 7}, Month: []int{11}},
+		}},
+		Daylight: []model.TimeZoneProperty{{
+			TimeZoneOffsetFrom: -5 * 60 * 60,
+			TimeZoneOffsetTo:   -4 * 60 * 60,
+			DTStart:            time.Date(1970, time.April, 26, 2, 0, 0, 0, time.UTC),
+			RRule:              &rrule.RRule{Frequency: rrule.FrequencyYearly, Weekday: []rrule.ByDay{{Weekday: rrule.WeekdaySunday, Interval: 1}}, Monthday: []int{8, 9, 10, 11, 12, 13, 14}, Month: []int{3}},
+		}},
+	}
+	fixed := &model.TimeZone{
+		TimeZoneID: "Fixed",
+		Standard: []model.TimeZoneProperty{{
+			TimeZoneOffsetFrom: 9 * 60 * 60,
+			TimeZoneOffsetTo:   9 * 60 * 60,
+			DTStart:            time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	testCases := []struct {
+		name     string
+		timeZone *model.TimeZone
+		wall     time.Time
+		expected int
+	}{
+		{name: "Winter", timeZone: europe, wall: time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC), expected: 60 * 60},
+		{name: "Summer", timeZone: europe, wall: time.Date(2025, time.July, 15, 12, 0, 0, 0, time.UTC), expected: 2 * 60 * 60},
+		{name: "Just before the switch to summer time", timeZone: europe, wall: time.Date(2025, time.March, 30, 1, 59, 0, 0, time.UTC), expected: 60 * 60},
+		{name: "At the switch to summer time", timeZone: europe, wall: time.Date(2025, time.March, 30, 3, 0, 0, 0, time.UTC), expected: 2 * 60 * 60},
+		{name: "Before the first onset", timeZone: europe, wall: time.Date(1980, time.July, 1, 0, 0, 0, 0, time.UTC), expected: 60 * 60},
+		{name: "BYMONTHDAY form in summer", timeZone: newYork, wall: time.Date(2025, time.March, 9, 12, 0, 0, 0, time.UTC), expected: -4 * 60 * 60},
+		{name: "BYMONTHDAY form in winter", timeZone: newYork, wall: time.Date(2025, time.March, 8, 12, 0, 0, 0, time.UTC), expected: -5 * 60 * 60},
+		{name: "Single observance without a rule", timeZone: fixed, wall: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), expected: 9 * 60 * 60},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			offset, ok := timeZoneOffset(testCase.timeZone, testCase.wall)
+			assert.True(t, ok)
+			assert.Equal(t, testCase.expected, offset)
+		})
+	}
+
+	_, ok := timeZoneOffset(&model.TimeZone{TimeZoneID: "Empty"}, time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
@@ -0,0 +1,97 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"io"
+	"iter"
+
+	"github.com/michael-gallo/simpleical/model"
+)
+
+// Decoder reads the top-level components of a calendar one at a time, instead of building the whole calendar
+// like IcalReader. Memory use is bounded by the largest single component, which makes it suitable for very large feeds.
+// Components are validated exactly as IcalReader validates them, and the same options apply.
+type Decoder struct {
+	parser  *parser
+	started bool
+	// pending is the component completed by the last line read.
+	pending model.TopLevelComponent
+	// err is returned by every call to Next once reading stopped, io.EOF when the calendar ended normally.
+	err error
+}
+
+// NewDecoder returns a Decoder reading from reader.
+func NewDecoder(reader io.Reader, opts ...Option) *Decoder {
+	decoder := &Decoder{parser: newParser(opts)}
+	decoder.parser.emit = func(component model.TopLevelComponent) {
+		decoder.pending = component
+	}
+	decoder.parser.lines = newLineReader(reader, decoder.parser.options.limits)
+	return decoder
+}
+
+// Next returns the next top-level component as soon as its END line has been read:
+// a *model.Event, *model.Todo, *model.Journal, *model.FreeBusy, *model.TimeZone or *model.Component.
+// It returns io.EOF once the reader is exhausted, and a *ParseError if the input is invalid.
+// Reading does not stop at the END:VCALENDAR line: the rest of the input is read as IcalReader reads it,
+// so content after it is reported as an error and a missing END:VCALENDAR is noticed.
+// After an error every further call returns the same error.
+func (d *Decoder) Next() (model.TopLevelComponent, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if !d.started {
+		d.started = true
+		if err := d.parser.start(); err != nil {
+			d.err = err
+			return nil, err
+		}
+	}
+	for {
+		done, err := d.parser.step()
+		if err != nil {
+			d.err = err
+			return nil, err
+		}
+		if done {
+			d.err = d.parser.finish()
+			if d.err == nil {
+				d.err = io.EOF
+			}
+		}
+		if d.pending != nil {
+			component := d.pending
+			d.pending = nil
+			return component, nil
+		}
+		if done {
+			return nil, d.err
+		}
+	}
+}
+
+// All returns an iterator over the remaining top-level components, see Next.
+// The iteration stops after the first error, which is yielded with a nil component. io.EOF is not yielded.
+func (d *Decoder) All() iter.Seq2[model.TopLevelComponent, error] {
+	return func(yield func(model.TopLevelComponent, error) bool) {
+		for {
+			component, err := d.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(component, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Calendar returns the VCALENDAR properties read so far, such as VERSION and PRODID.
+// Its component slices are always empty, as components are returned by Next instead.
+// Properties that follow the components in the input are only present once Next returned io.EOF.
+func (d *Decoder) Calendar() *model.Calendar {
+	return d.parser.calendar
+}
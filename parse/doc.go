@@ -1,6 +1,16 @@
 // Package parse parses iCalendar (RFC 5545) data into Go structs.
 //
 // It supports standard components including events, to-dos, journals,
-// free-busy, time zones, and alarms. See the model package for data
-// structures, and examples in this package for common entry points.
+// free-busy, time zones, and alarms. Components without a dedicated model
+// type, such as VAVAILABILITY or X- components, are kept as generic
+// model.Component values. See the model package for data structures, and
+// examples in this package for common entry points.
+//
+// Errors are returned as *ParseError, which locates the problem in the input.
+// IcalReaderLenient collects every problem instead of stopping at the first one.
+// Decoder reads large feeds one top-level component at a time.
+//
+// DATE and DATE-TIME values are returned as model.DateTime, which keeps the form they were written in.
+// A TZID is resolved against the VTIMEZONE components of the calendar first and the IANA time zone
+// database second. Programs running where no time zone database is installed can import time/tzdata.
 package parse
@@ -4,94 +4,186 @@
 
 package parse
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Severity classifies a problem found by IcalReaderLenient by what it cost.
+type Severity uint8
+
+const (
+	// SeverityWarning means the input deviated from RFC 5545, but nothing was lost, eg: a blank line.
+	SeverityWarning Severity = iota + 1
+	// SeverityError means a property or component was dropped from the calendar.
+	SeverityError
+	// SeverityFatal means the parse stopped, and the calendar is missing everything after the problem.
+	SeverityFatal
+)
+
+// String returns the lower case name of the severity, eg: warning.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityFatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseError describes where in the input a parse failed.
+// It wraps one of the sentinel errors declared in this package, so callers can match it with errors.Is,
+// and retrieve the location with errors.As.
+type ParseError struct {
+	// Severity is how IcalReaderLenient dealt with the problem. IcalReader stops at the first problem regardless.
+	Severity Severity
+	// Line is the 1-based number of the physical line on which the offending content line starts.
+	Line int
+	// Column is the 1-based byte column within Raw at which the problem starts.
+	// For errors raised while parsing a property it points at the start of the property value, otherwise it is 1.
+	Column int
+	// Offset is the byte offset in the input at which the offending content line starts.
+	Offset int64
+	// Path lists the open components, eg: VCALENDAR/VEVENT[3]/VALARM[0].
+	// Each index counts the components with the same name inside the same parent, starting at 0.
+	Path string
+	// Property is the name of the offending property, or BEGIN/END for component delimiters.
+	Property string
+	// Raw is the unfolded content line.
+	Raw string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error formats the location of the problem followed by the underlying error.
+func (e *ParseError) Error() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "line %d, column %d", e.Line, e.Column)
+	if e.Path != "" {
+		builder.WriteString(" in ")
+		builder.WriteString(e.Path)
+	}
+	if e.Property != "" {
+		builder.WriteString(" property ")
+		builder.WriteString(e.Property)
+	}
+	builder.WriteString(": ")
+	builder.WriteString(e.Err.Error())
+	return builder.String()
+}
+
+// Unwrap returns the underlying error, so errors.Is can match the sentinel errors.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
 
 // Calendar-level errors.
 var (
-	errNoCalendarFound                   = errors.New("empty calendar sent")
-	errInvalidCalendarFormatMissingBegin = errors.New("invalid calendar format: must start with BEGIN:VCALENDAR")
-	errInvalidCalendarFormatMissingEnd   = errors.New("invalid calendar format: must end with END:VCALENDAR")
-	errInvalidCalendarEmptyLine          = errors.New("invalid calendar format: must not contain empty lines")
-	errContentAfterEndBlock              = errors.New("content after END:VCALENDAR")
-	errTemplateInvalidEndBlock           = errors.New("invalid end block")
-	errTemplateInvalidStartBlock         = errors.New("invalid start block")
-	errMissingCalendarVersionProperty    = errors.New("calendar must have a VERSION property")
-	errMissingCalendarProdIDProperty     = errors.New("calendar must have a PRODID property")
+	ErrNoCalendarFound                   = errors.New("empty calendar sent")
+	ErrInvalidCalendarFormatMissingBegin = errors.New("invalid calendar format: must start with BEGIN:VCALENDAR")
+	ErrInvalidCalendarFormatMissingEnd   = errors.New("invalid calendar format: must end with END:VCALENDAR")
+	ErrInvalidCalendarEmptyLine          = errors.New("invalid calendar format: must not contain empty lines")
+	ErrContentAfterEndBlock              = errors.New("content after END:VCALENDAR")
+	ErrTemplateInvalidEndBlock           = errors.New("invalid end block")
+	ErrTemplateInvalidStartBlock         = errors.New("invalid start block")
+	ErrInvalidComponentNesting           = errors.New("component is not allowed inside its parent component")
+	ErrMissingCalendarVersionProperty    = errors.New("calendar must have a VERSION property")
+	ErrMissingCalendarProdIDProperty     = errors.New("calendar must have a PRODID property")
+	ErrInvalidCalendarProperty           = errors.New("invalid calendar property")
 
 	// General parsing errors.
-	errInvalidPropertyLine = errors.New("invalid property line in iCal data")
-	errDuplicateProperty   = errors.New("duplicate property")
+	ErrInvalidPropertyLine  = errors.New("invalid property line in iCal data")
+	ErrInvalidPropertyValue = errors.New("invalid property value")
+	ErrLimitExceeded        = errors.New("limit exceeded")
+	ErrDuplicateProperty    = errors.New("duplicate property")
 )
 
 // Event-specific errors.
 var (
-	errInvalidEventProperty = errors.New("invalid event property")
+	ErrInvalidEventProperty = errors.New("invalid event property")
 
-	errMissingEventUIDProperty     = errors.New("event must have a UID property")
-	errMissingEventDTStartProperty = errors.New("event must have a DTSTART property if no METHOD property is present for the top level calendar")
+	ErrMissingEventUIDProperty     = errors.New("event must have a UID property")
+	ErrMissingEventDTStampProperty = errors.New("event must have a DTSTAMP property")
+	ErrMissingEventDTStartProperty = errors.New("event must have a DTSTART property if no METHOD property is present for the top level calendar")
 
 	// Event duration property errors.
-	errInvalidDurationPropertyDtend = errors.New("invalid duration property in iCal Event: DTEND and DURATION are mutually exclusive")
+	ErrInvalidDurationPropertyDtend = errors.New("invalid duration property in iCal Event: DTEND and DURATION are mutually exclusive")
 
 	// Event geographic property errors.
-	errInvalidGeoProperty          = errors.New("invalid event property in iCal Event: GEO must be two floats separated by a semicolon")
-	errInvalidGeoPropertyLatitude  = errors.New("invalid latitude in iCal Event: GEO must be a float")
-	errInvalidGeoPropertyLongitude = errors.New("invalid longitude in iCal Event: GEO must be a float")
+	ErrInvalidGeoProperty          = errors.New("invalid GEO property: GEO must be two floats separated by a semicolon")
+	ErrInvalidGeoPropertyLatitude  = errors.New("invalid latitude in GEO property: latitude must be a float from -90 to 90")
+	ErrInvalidGeoPropertyLongitude = errors.New("invalid longitude in GEO property: longitude must be a float from -180 to 180")
 )
 
 // Todo-specific errors.
 var (
-	errInvalidTodoProperty = errors.New("invalid todo property")
+	ErrInvalidTodoProperty = errors.New("invalid todo property")
+
+	ErrMissingTodoUIDProperty = errors.New("todo must have a UID property")
 
-	errMissingTodoUIDProperty = errors.New("todo must have a UID property")
+	ErrMissingTodoDTStampProperty = errors.New("todo must have a DTSTAMP property")
 
-	errMissingTodoDTStartProperty = errors.New("todo must have a DTSTART property")
+	ErrMissingTodoDTStartProperty = errors.New("todo must have a DTSTART property")
 
 	// Todo duration property errors.
-	errInvalidDurationPropertyDue = errors.New("invalid duration property in iCal Todo: DUE and DURATION are mutually exclusive")
+	ErrInvalidDurationPropertyDue = errors.New("invalid duration property in iCal Todo: DUE and DURATION are mutually exclusive")
 )
 
 // Journal-specific errors.
 var (
-	errInvalidJournalProperty = errors.New("invalid journal property")
+	ErrInvalidJournalProperty = errors.New("invalid journal property")
 
-	errMissingJournalUIDProperty = errors.New("journal must have a UID property")
+	ErrMissingJournalUIDProperty = errors.New("journal must have a UID property")
 
-	errMissingJournalDTStartProperty = errors.New("journal must have a DTSTART property")
+	ErrMissingJournalDTStampProperty = errors.New("journal must have a DTSTAMP property")
+
+	ErrMissingJournalDTStartProperty = errors.New("journal must have a DTSTART property")
 )
 
 // FreeBusy-specific errors.
 var (
-	errInvalidFreeBusyProperty = errors.New("invalid freebusy property")
+	ErrInvalidFreeBusyProperty = errors.New("invalid freebusy property")
+
+	ErrMissingFreeBusyUIDProperty = errors.New("freebusy must have a UID property")
 
-	errMissingFreeBusyUIDProperty = errors.New("freebusy must have a UID property")
+	ErrMissingFreeBusyDTStampProperty = errors.New("freebusy must have a DTSTAMP property")
 
-	errInvalidFreeBusyFormat = errors.New("invalid FREEBUSY property format")
+	ErrInvalidFreeBusyFormat = errors.New("invalid FREEBUSY property format")
 
-	errMissingFreeBusyDTStartProperty = errors.New("freebusy must have a DTSTART property")
+	ErrMissingFreeBusyDTStartProperty = errors.New("freebusy must have a DTSTART property")
 )
 
 // Timezone-specific errors.
 var (
-	errInvalidTimezoneProperty     = errors.New("invalid timezone property")
-	errMissingTimezoneTZIDProperty = errors.New("timezone must have a TZID property")
+	ErrInvalidTimezoneProperty     = errors.New("invalid timezone property")
+	ErrMissingTimezoneTZIDProperty = errors.New("timezone must have a TZID property")
+	ErrUnknownTimeZone             = errors.New("TZID is neither defined by a VTIMEZONE nor in the IANA time zone database")
+	// ErrMissingTimezoneOffsetFromProperty and ErrMissingTimezoneOffsetToProperty are returned for a STANDARD
+	// or DAYLIGHT sub-component without a TZOFFSETFROM or a TZOFFSETTO property.
+	ErrMissingTimezoneOffsetFromProperty = errors.New("timezone observance must have a TZOFFSETFROM property")
+	ErrMissingTimezoneOffsetToProperty   = errors.New("timezone observance must have a TZOFFSETTO property")
 )
 
 // Alarm-specific errors.
 var (
-	errInvalidAlarmProperty = errors.New("invalid alarm property")
+	ErrInvalidAlarmProperty = errors.New("invalid alarm property")
 
-	errMissingAlarmActionProperty = errors.New("alarm must have an ACTION property")
+	ErrMissingAlarmActionProperty = errors.New("alarm must have an ACTION property")
 
-	errMissingAlarmTriggerProperty = errors.New("alarm must have a TRIGGER property")
+	ErrMissingAlarmTriggerProperty = errors.New("alarm must have a TRIGGER property")
 
-	errMissingAlarmDescriptionForDisplay = errors.New("DISPLAY alarm must have a DESCRIPTION property")
+	ErrMissingAlarmDescriptionForDisplay = errors.New("DISPLAY alarm must have a DESCRIPTION property")
 
-	errMissingAlarmDescriptionForEmail = errors.New("EMAIL alarm must have a DESCRIPTION property")
+	ErrMissingAlarmDescriptionForEmail = errors.New("EMAIL alarm must have a DESCRIPTION property")
 
-	errMissingAlarmSummaryForEmail = errors.New("EMAIL alarm must have a SUMMARY property")
+	ErrMissingAlarmSummaryForEmail = errors.New("EMAIL alarm must have a SUMMARY property")
 
-	errMissingAlarmAttendeesForEmail = errors.New("EMAIL alarm must have at least one ATTENDEE property")
+	ErrMissingAlarmAttendeesForEmail = errors.New("EMAIL alarm must have at least one ATTENDEE property")
 )
 
 // Property Setter errors.
@@ -99,6 +191,6 @@ var (
 const errDuplicatePropertyInComponentFormat = "%w: %s set twice in component %s"
 
 var (
-	errDuplicatePropertyInComponent = errors.New("duplicate property error")
-	errParseErrorInComponent        = errors.New("parse error in component")
+	ErrDuplicatePropertyInComponent = errors.New("duplicate property error")
+	ErrParseErrorInComponent        = errors.New("parse error in component")
 )
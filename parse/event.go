@@ -2,10 +2,6 @@ package parse
 
 import (
 	"fmt"
-	"net/url"
-	"strconv"
-	"strings"
-	"time"
 
 	"github.com/michael-gallo/simpleical/model"
 	"github.com/michael-gallo/simpleical/rrule"
@@ -13,95 +9,139 @@ import (
 
 const eventLocation = "Event"
 
+// Values RFC 5545 defines for the enumerated properties of a VEVENT.
+var (
+	eventStatuses = []model.EventStatus{model.EventStatusTentative, model.EventStatusConfirmed, model.EventStatusCancelled}
+	eventTransps  = []model.EventTransp{model.EventTranspOpaque, model.EventTranspTransparent}
+)
+
 // parseEventProperty parses a single property line and adds it to the provided vevent.
-func parseEventProperty(propertyName string, value string, params map[string]string, event *model.Event) error {
+func (p *parser) parseEventProperty(propertyName string, value string, params model.Parameters, event *model.Event) error {
 	switch model.EventToken(propertyName) {
 	case model.EventTokenDtstart:
-		return setOnceTimeProperty(&event.Start, value, propertyName, eventLocation)
+		return p.setOnceDateTimeProperty(&event.Start, value, params, propertyName, eventLocation)
 	case model.EventTokenDTStamp:
 		return setOnceTimeProperty(&event.DTStamp, value, propertyName, eventLocation)
 
 	// End and Duration are mutually exclusive
 	case model.EventTokenDtend:
 		if event.Duration != 0 {
-			return errInvalidDurationPropertyDtend
+			return ErrInvalidDurationPropertyDtend
 		}
-		return setOnceTimeProperty(&event.End, value, propertyName, eventLocation)
+		return p.setOnceDateTimeProperty(&event.End, value, params, propertyName, eventLocation)
 	case model.EventTokenDuration:
-		if event.End != (time.Time{}) {
-			return errInvalidDurationPropertyDtend
+		if !event.End.IsZero() {
+			return ErrInvalidDurationPropertyDtend
 		}
 		return setOnceDurationProperty(&event.Duration, value, propertyName, eventLocation)
 	case model.EventTokenLastModified:
 		return setOnceTimeProperty(&event.LastModified, value, propertyName, eventLocation)
 
 	case model.EventTokenSummary:
-		return setOnceProperty(&event.Summary, value, propertyName, eventLocation)
+		return p.setOnceTextProperty(&event.Summary, value, params, propertyName, eventLocation)
 	case model.EventTokenDescription:
-		return setOnceProperty(&event.Description, value, propertyName, eventLocation)
+		return p.setOnceTextProperty(&event.Description, value, params, propertyName, eventLocation)
 	case model.EventTokenLocation:
-		return setOnceProperty(&event.Location, value, propertyName, eventLocation)
+		return p.setOnceTextProperty(&event.Location, value, params, propertyName, eventLocation)
 	case model.EventTokenUID:
-		return setOnceProperty(&event.UID, value, propertyName, eventLocation)
+		return setOnceProperty(&event.UID, p.text(value), propertyName, eventLocation)
 	case model.EventTokenContact:
-		event.Contacts = append(event.Contacts, value)
-		return nil
+		return p.appendTextProperty(&event.Contacts, value, params, propertyName)
 
 	case model.EventTokenStatus:
-		event.Status = model.EventStatus(value)
+		status, err := enumValue(&p.options, value, propertyName, eventStatuses, false)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&event.Status, status, propertyName, eventLocation)
 	case model.EventTokenTransp:
-		return setOnceProperty(&event.Transp, model.EventTransp(value), propertyName, eventLocation)
+		transp, err := enumValue(&p.options, value, propertyName, eventTransps, false)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&event.Transp, transp, propertyName, eventLocation)
 	case model.EventTokenSequence:
-		return setOnceIntProperty(&event.Sequence, value, propertyName, eventLocation)
+		return p.setOnceIntProperty(&event.Sequence, value, propertyName, eventLocation)
 	case model.EventTokenOrganizer:
 		organizer, err := parseOrganizer(value, params)
 		if err != nil {
 			return err
 		}
-		event.Organizer = organizer
+		return setOnceProperty(&event.Organizer, organizer, propertyName, eventLocation)
 	case model.EventTokenComment:
-		event.Comment = append(event.Comment, value)
+		return p.appendTextProperty(&event.Comment, value, params, propertyName)
 	case model.EventTokenCategories:
-		event.Categories = append(event.Categories, strings.Split(value, ",")...)
+		event.Categories = append(event.Categories, p.textList(value)...)
 	case model.EventTokenGeo:
-		if event.Geo != nil {
-			return fmt.Errorf("%w: %s", errDuplicateProperty, propertyName)
+		return setOnceGeoProperty(&event.Geo, value, propertyName, eventLocation)
+	case model.EventTokenRRule:
+		rule, err := rrule.ParseRRule(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidPropertyValue, propertyName, err)
 		}
-		// Geo must be two floats separted by a colon
-		latitudeString, longitudeString, found := strings.Cut(value, ";")
-		if !found {
-			return errInvalidGeoProperty
+		return setOnceProperty(&event.RRule, rule, propertyName, eventLocation)
+	case model.EventTokenClass:
+		class, err := enumValue(&p.options, value, propertyName, classes, true)
+		if err != nil {
+			return err
 		}
-		latitude, err := strconv.ParseFloat(latitudeString, 64)
+		return setOnceProperty(&event.Class, class, propertyName, eventLocation)
+	case model.EventTokenCreated:
+		return setOnceTimeProperty(&event.Created, value, propertyName, eventLocation)
+	case model.EventTokenPriority:
+		return p.setOncePriorityProperty(&event.Priority, value, propertyName, eventLocation)
+	case model.EventTokenURL:
+		return setOnceProperty(&event.URL, value, propertyName, eventLocation)
+	case model.EventTokenRecurrenceID:
+		return p.setOnceDateTimeProperty(&event.RecurrenceID, value, params, propertyName, eventLocation)
+
+	// Repeatable properties
+	case model.EventTokenAttach:
+		attachment, err := parseAttachment(value, params)
 		if err != nil {
-			return errInvalidGeoPropertyLatitude
+			return err
 		}
-		longitude, err := strconv.ParseFloat(longitudeString, 64)
+		event.Attach = append(event.Attach, attachment)
+	case model.EventTokenAttendee:
+		attendee, err := p.parseAttendee(value, params)
 		if err != nil {
-			return errInvalidGeoPropertyLongitude
+			return err
 		}
-		event.Geo = append(event.Geo, latitude, longitude)
-	case model.EventTokenRRule:
-		rule, err := rrule.ParseRRule(value)
+		event.Attendees = append(event.Attendees, attendee)
+	case model.EventTokenExceptionDates:
+		return p.appendDateTimeProperty(&event.ExceptionDates, value, params, propertyName, eventLocation)
+	case model.EventTokenRdate:
+		return p.appendRdateProperty(&event.Rdate, &event.IANAProp, value, params, propertyName, eventLocation)
+	case model.EventTokenRequestStatus:
+		requestStatus, err := p.parseRequestStatus(value, params)
 		if err != nil {
 			return err
 		}
-		return setOnceProperty(&event.RRule, rule, propertyName, eventLocation)
+		event.RequestStatus = append(event.RequestStatus, requestStatus)
+	case model.EventTokenRelated:
+		relation, err := p.parseRelation(value, params)
+		if err != nil {
+			return err
+		}
+		event.Related = append(event.Related, relation)
+	case model.EventTokenResources:
+		return p.appendTextListProperty(&event.Resources, value, params, propertyName)
 	default:
-		return fmt.Errorf("%w: %s", errInvalidEventProperty, propertyName)
+		return p.appendExtraProperty(&event.XProp, &event.IANAProp, propertyName, value, params, ErrInvalidEventProperty)
 	}
 	return nil
 }
 
 // parseOrganizer parses a calendar line starting with ORGANIZER.
-func parseOrganizer(value string, params map[string]string) (*model.Organizer, error) {
+func parseOrganizer(value string, params model.Parameters) (*model.Organizer, error) {
 	organizer := &model.Organizer{}
-	for propName, propValue := range params {
+	for propName, propValues := range params {
+		propValue := propValues[0]
 		switch propName {
 		case "CN":
 			organizer.CommonName = propValue
 		case "DIR":
-			parsedURI, err := url.Parse(propValue)
+			parsedURI, err := parseURI(propValue, "ORGANIZER DIR")
 			if err != nil {
 				return nil, err
 			}
@@ -109,20 +149,20 @@ func parseOrganizer(value string, params map[string]string) (*model.Organizer, e
 		case "LANGUAGE":
 			organizer.Language = propValue
 		case "SENT-BY":
-			parsedURI, err := url.Parse(propValue)
+			parsedURI, err := parseURI(propValue, "ORGANIZER SENT-BY")
 			if err != nil {
 				return nil, err
 			}
 			organizer.SentBy = parsedURI
 		default:
 			if organizer.OtherParams == nil {
-				organizer.OtherParams = make(map[string]string)
+				organizer.OtherParams = make(model.Parameters)
 			}
-			organizer.OtherParams[propName] = propValue
+			organizer.OtherParams[propName] = propValues
 		}
 	}
 
-	parsedURI, err := url.Parse(value)
+	parsedURI, err := parseURI(value, "ORGANIZER")
 	if err != nil {
 		return nil, err
 	}
@@ -132,12 +172,12 @@ func parseOrganizer(value string, params map[string]string) (*model.Organizer, e
 }
 
 // validateEvent ensures that all required values are present for an event
-func validateEvent(event model.Event) error {
+func validateEvent(event *model.Event) error {
 	if event.UID == "" {
-		return errMissingEventUIDProperty
+		return ErrMissingEventUIDProperty
 	}
 	if event.Start.IsZero() {
-		return errMissingEventDTStartProperty
+		return ErrMissingEventDTStartProperty
 	}
 	return nil
 }
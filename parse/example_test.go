@@ -1,9 +1,11 @@
 package parse_test
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/michael-gallo/simpleical/model"
 	"github.com/michael-gallo/simpleical/parse"
 )
 
@@ -78,3 +80,47 @@ func ExampleIcalReader() {
 	// America/Detroit
 	// Event Summary
 }
+
+func ExampleParseError() {
+	input := `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Event//Event Calendar//EN
+BEGIN:VEVENT
+UID:13235@example.com
+DTSTART:IAMINVALID
+END:VEVENT
+END:VCALENDAR
+`
+	_, err := parse.IcalString(input)
+
+	var parseError *parse.ParseError
+	if errors.As(err, &parseError) {
+		fmt.Println(parseError.Line, parseError.Column)
+		fmt.Println(parseError.Path)
+		fmt.Println(parseError.Raw)
+	}
+	fmt.Println(errors.Is(err, parse.ErrParseErrorInComponent))
+	// Output:
+	// 6 9
+	// VCALENDAR/VEVENT[0]
+	// DTSTART:IAMINVALID
+	// true
+}
+
+func ExampleDecoder() {
+	decoder := parse.NewDecoder(strings.NewReader(testIcalString))
+	for component, err := range decoder.All() {
+		if err != nil {
+			panic(err)
+		}
+		switch component := component.(type) {
+		case *model.Event:
+			fmt.Println("event:", component.Summary)
+		case *model.TimeZone:
+			fmt.Println("timezone:", component.TimeZoneID)
+		}
+	}
+	// Output:
+	// timezone: America/Detroit
+	// event: Event Summary
+}
@@ -2,7 +2,6 @@ package parse
 
 import (
 	"fmt"
-	"net/url"
 	"strings"
 	"time"
 
@@ -13,14 +12,14 @@ import (
 const freeBusyLocation = "FreeBusy"
 
 // parseFreeBusyProperty parses a single property line and adds it to the provided freebusy.
-func parseFreeBusyProperty(propertyName string, value string, params map[string]string, freeBusy *model.FreeBusy) error {
+func (p *parser) parseFreeBusyProperty(propertyName string, value string, params model.Parameters, freeBusy *model.FreeBusy) error {
 	switch model.FreeBusyToken(propertyName) {
 	case model.FreeBusyTokenDTStamp:
 		return setOnceTimeProperty(&freeBusy.DTStamp, value, propertyName, freeBusyLocation)
 	case model.FreeBusyTokenUID:
-		return setOnceProperty(&freeBusy.UID, value, propertyName, freeBusyLocation)
+		return setOnceProperty(&freeBusy.UID, p.text(value), propertyName, freeBusyLocation)
 	case model.FreeBusyTokenContact:
-		return setOnceProperty(&freeBusy.Contact, value, propertyName, freeBusyLocation)
+		return p.setOnceTextProperty(&freeBusy.Contact, value, params, propertyName, freeBusyLocation)
 	case model.FreeBusyTokenDTStart:
 		return setOnceTimeProperty(&freeBusy.DTStart, value, propertyName, freeBusyLocation)
 	case model.FreeBusyTokenDTEnd:
@@ -30,19 +29,19 @@ func parseFreeBusyProperty(propertyName string, value string, params map[string]
 		if err != nil {
 			return err
 		}
-		freeBusy.Organizer = organizer
+		return setOnceProperty(&freeBusy.Organizer, organizer, propertyName, freeBusyLocation)
 	case model.FreeBusyTokenURL:
 		return setOnceProperty(&freeBusy.URL, value, propertyName, freeBusyLocation)
 
 	// Repeatable properties
 	case model.FreeBusyTokenAttendee:
-		parsedURL, err := url.Parse(value)
+		attendee, err := p.parseAttendee(value, params)
 		if err != nil {
 			return err
 		}
-		freeBusy.Attendees = append(freeBusy.Attendees, *parsedURL)
+		freeBusy.Attendees = append(freeBusy.Attendees, attendee)
 	case model.FreeBusyTokenComment:
-		freeBusy.Comment = append(freeBusy.Comment, value)
+		return p.appendTextProperty(&freeBusy.Comment, value, params, propertyName)
 	case model.FreeBusyTokenFreeBusy:
 		fbTime, err := parseFreeBusyTime(value)
 		if err != nil {
@@ -50,9 +49,13 @@ func parseFreeBusyProperty(propertyName string, value string, params map[string]
 		}
 		freeBusy.FreeBusy = append(freeBusy.FreeBusy, fbTime)
 	case model.FreeBusyTokenRequestStatus:
-		freeBusy.RequestStatus = append(freeBusy.RequestStatus, value)
+		requestStatus, err := p.parseRequestStatus(value, params)
+		if err != nil {
+			return err
+		}
+		freeBusy.RequestStatus = append(freeBusy.RequestStatus, requestStatus)
 	default:
-		return fmt.Errorf("%w: %s", errInvalidFreeBusyProperty, propertyName)
+		return p.appendExtraProperty(&freeBusy.XProp, &freeBusy.IANAProp, propertyName, value, params, ErrInvalidFreeBusyProperty)
 	}
 	return nil
 }
@@ -64,19 +67,19 @@ func parseFreeBusyTime(value string) (model.FreeBusyTime, error) {
 	// Extract start time (everything before first '/')
 	startStr, remaining, found := strings.Cut(value, "/")
 	if !found {
-		return model.FreeBusyTime{}, fmt.Errorf("%w: %s", errInvalidFreeBusyFormat, value)
+		return model.FreeBusyTime{}, fmt.Errorf("%w: %s", ErrInvalidFreeBusyFormat, value)
 	}
 
 	startTime, err := icaldur.ParseIcalTime(startStr)
 	if err != nil {
-		return model.FreeBusyTime{}, fmt.Errorf("invalid start time in FREEBUSY property: %w", err)
+		return model.FreeBusyTime{}, fmt.Errorf("%w: invalid start time %s: %w", ErrInvalidFreeBusyFormat, startStr, err)
 	}
 
 	// Extract end time and optional status (everything after first '/')
 	endStr, statusStr, hasStatus := strings.Cut(remaining, "/")
 	endTime, err := icaldur.ParseIcalTime(endStr)
 	if err != nil {
-		return model.FreeBusyTime{}, fmt.Errorf("invalid end time in FREEBUSY property: %w", err)
+		return model.FreeBusyTime{}, fmt.Errorf("%w: invalid end time %s: %w", ErrInvalidFreeBusyFormat, endStr, err)
 	}
 
 	fbTime := model.FreeBusyTime{
@@ -98,10 +101,10 @@ func parseFreeBusyTime(value string) (model.FreeBusyTime, error) {
 // validateFreeBusy ensures that all required values are present for a freebusy.
 func validateFreeBusy(freeBusy *model.FreeBusy) error {
 	if freeBusy.UID == "" {
-		return errMissingFreeBusyUIDProperty
+		return ErrMissingFreeBusyUIDProperty
 	}
 	if time.Time.IsZero(freeBusy.DTStart) {
-		return errMissingFreeBusyDTStartProperty
+		return ErrMissingFreeBusyDTStartProperty
 	}
 	return nil
 }
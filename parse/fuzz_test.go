@@ -0,0 +1,39 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"strings"
+	"testing"
+)
+
+// FuzzIcalString checks that no input makes the parser panic in either mode, and that the results are consistent.
+func FuzzIcalString(f *testing.F) {
+	seeds := []string{
+		"BEGIN:VCALENDAR\nVERSION:2.0\nPRODID:-//Test//EN\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:STANDARD\nTZOFFSETTO:+0100\nEND:STANDARD\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nEND:VALARM\nEND:VEVENT\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:VJOURNAL\nBEGIN:VALARM\nACTION:DISPLAY\nEND:VALARM\nEND:VJOURNAL\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nBEGIN:X-THING\nBEGIN:VEVENT\nEND:VEVENT\nEND:X-THING\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nEND:VCALENDAR\nEND:VCALENDAR\n",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		calendar, err := IcalString(input)
+		if err == nil && calendar == nil {
+			t.Fatal("nil calendar returned without an error")
+		}
+		if err != nil && calendar != nil {
+			t.Fatalf("calendar returned together with error %v", err)
+		}
+
+		calendar, diagnostics := IcalReaderLenient(strings.NewReader(input))
+		if calendar == nil && (len(diagnostics) == 0 || diagnostics[len(diagnostics)-1].Severity != SeverityFatal) {
+			t.Fatal("lenient parse returned a nil calendar without a fatal problem")
+		}
+	})
+}
@@ -1,29 +1,33 @@
 package parse
 
 import (
-	"fmt"
-	"net/url"
-	"strings"
-	"time"
-
 	"github.com/michael-gallo/simpleical/model"
 )
 
 const journalLocation = "Journal"
 
+// Values RFC 5545 defines for the enumerated properties of a VJOURNAL.
+var (
+	journalStatuses = []model.JournalStatus{model.JournalStatusDraft, model.JournalStatusFinal, model.JournalStatusCancelled}
+)
+
 // parseJournalProperty parses a single property line and adds it to the provided journal.
-func parseJournalProperty(propertyName string, value string, params map[string]string, journal *model.Journal) error {
+func (p *parser) parseJournalProperty(propertyName string, value string, params model.Parameters, journal *model.Journal) error {
 	switch model.JournalToken(propertyName) {
 	case model.JournalTokenDTStamp:
 		return setOnceTimeProperty(&journal.DTStamp, value, propertyName, journalLocation)
 	case model.JournalTokenUID:
-		return setOnceProperty(&journal.UID, value, propertyName, journalLocation)
+		return setOnceProperty(&journal.UID, p.text(value), propertyName, journalLocation)
 	case model.JournalTokenClass:
-		return setOnceProperty(&journal.Class, model.JournalClass(value), propertyName, journalLocation)
+		class, err := enumValue(&p.options, value, propertyName, classes, true)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&journal.Class, class, propertyName, journalLocation)
 	case model.JournalTokenCreated:
 		return setOnceTimeProperty(&journal.Created, value, propertyName, journalLocation)
 	case model.JournalTokenDTStart:
-		return setOnceTimeProperty(&journal.DTStart, value, propertyName, journalLocation)
+		return p.setOnceDateTimeProperty(&journal.DTStart, value, params, propertyName, journalLocation)
 	case model.JournalTokenLastModified:
 		return setOnceTimeProperty(&journal.LastModified, value, propertyName, journalLocation)
 	case model.JournalTokenOrganizer:
@@ -31,46 +35,62 @@ func parseJournalProperty(propertyName string, value string, params map[string]s
 		if err != nil {
 			return err
 		}
-		journal.Organizer = organizer
+		return setOnceProperty(&journal.Organizer, organizer, propertyName, journalLocation)
 	case model.JournalTokenRecurrenceID:
-		return setOnceTimeProperty(&journal.RecurrenceID, value, propertyName, journalLocation)
+		return p.setOnceDateTimeProperty(&journal.RecurrenceID, value, params, propertyName, journalLocation)
 	case model.JournalTokenSequence:
-		return setOnceIntProperty(&journal.Sequence, value, propertyName, journalLocation)
+		return p.setOnceIntProperty(&journal.Sequence, value, propertyName, journalLocation)
 	case model.JournalTokenStatus:
-		journal.Status = model.JournalStatus(value)
+		status, err := enumValue(&p.options, value, propertyName, journalStatuses, false)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&journal.Status, status, propertyName, journalLocation)
 	case model.JournalTokenSummary:
-		return setOnceProperty(&journal.Summary, value, propertyName, journalLocation)
+		return p.setOnceTextProperty(&journal.Summary, value, params, propertyName, journalLocation)
 	case model.JournalTokenURL:
 		return setOnceProperty(&journal.URL, value, propertyName, journalLocation)
 
 	// Repeatable properties
 	case model.JournalTokenAttach:
-		journal.Attach = append(journal.Attach, value)
+		attachment, err := parseAttachment(value, params)
+		if err != nil {
+			return err
+		}
+		journal.Attach = append(journal.Attach, attachment)
 		return nil
 	case model.JournalTokenAttendee:
-		parsedURL, err := url.Parse(value)
+		attendee, err := p.parseAttendee(value, params)
 		if err != nil {
 			return err
 		}
-		journal.Attendees = append(journal.Attendees, *parsedURL)
+		journal.Attendees = append(journal.Attendees, attendee)
 	case model.JournalTokenCategories:
-		journal.Categories = append(journal.Categories, strings.Split(value, ",")...)
+		journal.Categories = append(journal.Categories, p.textList(value)...)
 	case model.JournalTokenComment:
-		journal.Comment = append(journal.Comment, value)
+		return p.appendTextProperty(&journal.Comment, value, params, propertyName)
 	case model.JournalTokenContact:
-		journal.Contacts = append(journal.Contacts, value)
+		return p.appendTextProperty(&journal.Contacts, value, params, propertyName)
 	case model.JournalTokenDescription:
-		journal.Description = append(journal.Description, value)
+		return p.appendTextProperty(&journal.Description, value, params, propertyName)
 	case model.JournalTokenExceptionDates:
-		return appendTimeProperty(&journal.ExceptionDates, value, propertyName, journalLocation)
+		return p.appendDateTimeProperty(&journal.ExceptionDates, value, params, propertyName, journalLocation)
 	case model.JournalTokenRelated:
-		journal.Related = append(journal.Related, value)
+		relation, err := p.parseRelation(value, params)
+		if err != nil {
+			return err
+		}
+		journal.Related = append(journal.Related, relation)
 	case model.JournalTokenRdate:
-		return appendTimeProperty(&journal.Rdate, value, propertyName, journalLocation)
+		return p.appendRdateProperty(&journal.Rdate, &journal.IANAProp, value, params, propertyName, journalLocation)
 	case model.JournalTokenRequestStatus:
-		journal.RequestStatus = append(journal.RequestStatus, value)
+		requestStatus, err := p.parseRequestStatus(value, params)
+		if err != nil {
+			return err
+		}
+		journal.RequestStatus = append(journal.RequestStatus, requestStatus)
 	default:
-		return fmt.Errorf("%w: %s", errInvalidJournalProperty, propertyName)
+		return p.appendExtraProperty(&journal.XProp, &journal.IANAProp, propertyName, value, params, ErrInvalidJournalProperty)
 	}
 	return nil
 }
@@ -78,10 +98,10 @@ func parseJournalProperty(propertyName string, value string, params map[string]s
 // validateJournal ensures that all required values are present for a journal.
 func validateJournal(journal *model.Journal) error {
 	if journal.UID == "" {
-		return errMissingJournalUIDProperty
+		return ErrMissingJournalUIDProperty
 	}
-	if time.Time.IsZero(journal.DTStart) {
-		return errMissingJournalDTStartProperty
+	if journal.DTStart.IsZero() {
+		return ErrMissingJournalDTStartProperty
 	}
 	return nil
 }
@@ -0,0 +1,151 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+// Option configures optional parser behavior. Options are passed to IcalReaderWithOptions.
+type Option func(*options)
+
+// options holds the configuration of a single parse.
+// The zero value is the default behavior of IcalReader.
+type options struct {
+	// rawText disables decoding of TEXT value escapes.
+	rawText bool
+
+	// requireDTStamp rejects events, to-dos, journals and free/busy components without a DTSTAMP.
+	requireDTStamp bool
+	// strictEnums rejects enumerated property values that RFC 5545 does not define.
+	strictEnums bool
+	// rejectUnknownProperties rejects IANA properties that have no dedicated field.
+	rejectUnknownProperties bool
+	// requireKnownTimeZones rejects TZIDs that are neither defined by a VTIMEZONE nor in the IANA time zone database.
+	requireKnownTimeZones bool
+
+	// allowBlankLines skips blank lines instead of rejecting them.
+	allowBlankLines bool
+	// keepTrailingSpaces disables trimming of spaces at the end of content lines.
+	keepTrailingSpaces bool
+	// skipByteOrderMark ignores a UTF-8 byte order mark before BEGIN:VCALENDAR.
+	skipByteOrderMark bool
+	// allowMissingEnd closes components that are still open at the end of the input.
+	allowMissingEnd bool
+
+	limits Limits
+}
+
+// Limits caps the resources a single parse may use. Set them when parsing untrusted input.
+// A zero field means no limit, so the zero value imposes no limits at all, which is the default.
+// Exceeding a limit stops the parse with an error wrapping ErrLimitExceeded, also in lenient mode.
+type Limits struct {
+	// MaxLineLength caps the length in bytes of a content line after unfolding.
+	MaxLineLength int
+	// MaxBytes caps the length in bytes of the whole input.
+	MaxBytes int64
+	// MaxComponents caps the number of components, at any depth, not counting the VCALENDAR itself.
+	// For IcalReaderAll it counts the components of every calendar in the stream together.
+	MaxComponents int
+	// MaxPropertiesPerComponent caps the number of properties in any single component.
+	MaxPropertiesPerComponent int
+	// MaxParameterValues caps the number of parameter values on a single property.
+	MaxParameterValues int
+}
+
+// WithRawText keeps TEXT values (SUMMARY, DESCRIPTION, LOCATION, COMMENT and so on) exactly as they
+// appear in the input instead of decoding their backslash escapes.
+// List values such as CATEGORIES and RESOURCES are still split on unescaped commas,
+// but each item keeps its escapes. Use this when the original text must be preserved losslessly.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.11
+func WithRawText() Option {
+	return func(o *options) {
+		o.rawText = true
+	}
+}
+
+// WithLimits caps the resources a parse may use, see Limits.
+func WithLimits(limits Limits) Option {
+	return func(o *options) {
+		o.limits = limits
+	}
+}
+
+// WithStrict enables every check RFC 5545 requires but the parser skips by default,
+// currently WithRequireDTStamp, WithStrictEnums and WithRequireKnownTimeZones.
+func WithStrict() Option {
+	return func(o *options) {
+		o.requireDTStamp = true
+		o.strictEnums = true
+		o.requireKnownTimeZones = true
+	}
+}
+
+// WithRequireDTStamp rejects VEVENT, VTODO, VJOURNAL and VFREEBUSY components without a DTSTAMP property.
+// DTSTAMP is required by RFC 5545, but often missing in real life calendars, so it is optional by default.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.7.2
+func WithRequireDTStamp() Option {
+	return func(o *options) {
+		o.requireDTStamp = true
+	}
+}
+
+// WithStrictEnums rejects values of STATUS, TRANSP, CLASS and ACTION, of the ROLE, PARTSTAT and CUTYPE
+// parameters of ATTENDEE, and of the RELTYPE parameter of RELATED-TO, that RFC 5545 does not define.
+// All of them except STATUS and TRANSP still accept X- names, which the RFC allows for experimental values.
+// By default any value is accepted and stored as is.
+func WithStrictEnums() Option {
+	return func(o *options) {
+		o.strictEnums = true
+	}
+}
+
+// WithRequireKnownTimeZones rejects a TZID parameter that is neither defined by a VTIMEZONE of the calendar
+// nor in the IANA time zone database, with ErrUnknownTimeZone. RFC 5545 requires every TZID to be defined,
+// but vendor TZIDs are common in real life calendars, so by default such a value keeps its wall clock time
+// and is marked as model.DateTime.Unresolved.
+// With a Decoder, a VTIMEZONE must come before the components that use its TZID to be known.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.2.19
+func WithRequireKnownTimeZones() Option {
+	return func(o *options) {
+		o.requireKnownTimeZones = true
+	}
+}
+
+// WithRejectUnknownProperties rejects properties that have no dedicated field in the model, instead of keeping
+// them in the IANAProp map of their component. X- properties are still kept in XProp.
+func WithRejectUnknownProperties() Option {
+	return func(o *options) {
+		o.rejectUnknownProperties = true
+	}
+}
+
+// WithAllowBlankLines skips blank lines, which some producers emit between components.
+// RFC 5545 does not allow them, so they are rejected by default.
+func WithAllowBlankLines() Option {
+	return func(o *options) {
+		o.allowBlankLines = true
+	}
+}
+
+// WithKeepTrailingSpaces keeps spaces at the end of content lines as part of the value.
+// By default they are trimmed, see the deviations from spec in the README.
+func WithKeepTrailingSpaces() Option {
+	return func(o *options) {
+		o.keepTrailingSpaces = true
+	}
+}
+
+// WithSkipByteOrderMark ignores a UTF-8 byte order mark at the start of the input,
+// which is written by some Windows producers.
+func WithSkipByteOrderMark() Option {
+	return func(o *options) {
+		o.skipByteOrderMark = true
+	}
+}
+
+// WithAllowMissingEnd accepts input that ends before all components are closed, eg: a truncated download,
+// by closing the open components as if their END lines were present.
+func WithAllowMissingEnd() Option {
+	return func(o *options) {
+		o.allowMissingEnd = true
+	}
+}
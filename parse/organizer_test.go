@@ -9,7 +9,7 @@ import (
 )
 
 func BenchmarkParseOrganizer(b *testing.B) {
-	params := map[string]string{"CN": "My Org"}
+	params := model.Parameters{"CN": {"My Org"}}
 	value := "MAILTO:dc@example.com"
 	for b.Loop() {
 		_, _ = parseOrganizer(value, params)
@@ -20,14 +20,14 @@ func TestParseOrganizer(t *testing.T) {
 	testCases := []struct {
 		name              string
 		value             string
-		params            map[string]string
+		params            model.Parameters
 		expectedOrganizer *model.Organizer
 		expectedError     error
 	}{
 		{
 			name:              "Valid organizer line",
 			value:             "MAILTO:dc@example.com",
-			params:            map[string]string{"CN": "My Org"},
+			params:            model.Parameters{"CN": {"My Org"}},
 			expectedOrganizer: &model.Organizer{CommonName: "My Org", CalAddress: &url.URL{Scheme: "mailto", Opaque: "dc@example.com"}},
 			expectedError:     nil,
 		},
@@ -40,7 +40,7 @@ func TestParseOrganizer(t *testing.T) {
 		{
 			name:   "Mailto has a port",
 			value:  "MAILTO:dc@example.com:8080",
-			params: map[string]string{"CN": "My Org"},
+			params: model.Parameters{"CN": {"My Org"}},
 			expectedOrganizer: &model.Organizer{
 				CommonName: "My Org",
 				CalAddress: &url.URL{Scheme: "mailto", Opaque: "dc@example.com:8080"},
@@ -50,7 +50,7 @@ func TestParseOrganizer(t *testing.T) {
 		{
 			name:   "Valid organizer line with non MAILTO URI",
 			value:  "http://www.ietf.org/rfc/rfc2396.txt",
-			params: map[string]string{"CN": "My Org"},
+			params: model.Parameters{"CN": {"My Org"}},
 			expectedOrganizer: &model.Organizer{
 				CommonName: "My Org",
 				CalAddress: &url.URL{Scheme: "http", Host: "www.ietf.org", Path: "/rfc/rfc2396.txt"},
@@ -60,19 +60,19 @@ func TestParseOrganizer(t *testing.T) {
 		{
 			name:  "Valid organizer line with quoted string",
 			value: "mailto:jsmith@example.com",
-			params: map[string]string{
-				"MISCFIELD":  "TEST",
-				"MISCFIELD2": "TEST2",
-				"CN":         "JohnSmith",
-				"DIR":        "ldap://example.com:6666/o=DC%20Associates,c=US???(cn=John%20Smith)",
+			params: model.Parameters{
+				"MISCFIELD":  {"TEST"},
+				"MISCFIELD2": {"TEST2"},
+				"CN":         {"JohnSmith"},
+				"DIR":        {"ldap://example.com:6666/o=DC%20Associates,c=US???(cn=John%20Smith)"},
 			},
 			expectedOrganizer: &model.Organizer{
 				CommonName: "JohnSmith",
 				CalAddress: &url.URL{Scheme: "mailto", Opaque: "jsmith@example.com"},
 				Directory:  &url.URL{Scheme: "ldap", Host: "example.com:6666", Path: "/o=DC Associates,c=US", RawQuery: "??(cn=John%20Smith)"},
-				OtherParams: map[string]string{
-					"MISCFIELD":  "TEST",
-					"MISCFIELD2": "TEST2",
+				OtherParams: model.Parameters{
+					"MISCFIELD":  {"TEST"},
+					"MISCFIELD2": {"TEST2"},
 				},
 			},
 		},
@@ -5,31 +5,82 @@
 package parse
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/michael-gallo/simpleical/model"
 )
 
-// parserState represents the current parsing state using a single integer.
-type parserState uint8
-
-const (
-	stateCalendar parserState = iota
-	stateEvent
-	stateTimezone
-	stateTodo
-	stateJournal
-	stateFreebusy
-	stateEventAlarm
-	stateTodoAlarm
-	stateStandard
-	stateDaylight
-	stateFinished
-)
+// byteOrderMark is the UTF-8 encoding of U+FEFF.
+const byteOrderMark = "\uFEFF"
+
+// parser holds the state of a single parse.
+type parser struct {
+	options options
+
+	calendar *model.Calendar
+	lines    *lineReader
+	params   model.Parameters
+
+	// multiple allows further VCALENDAR objects after the first one. Completed calendars are kept in calendars.
+	multiple  bool
+	calendars []*model.Calendar
+
+	// components counts the components opened so far, see Limits.MaxComponents.
+	components int
+
+	// timeZones holds the VTIMEZONE components of the current calendar by TZID, even when they are emitted.
+	// locations caches the IANA time zones looked up for a TZID, nil if there is none.
+	// unresolvedTZIDs are the TZIDs that were used before they were defined, each with the location of the
+	// property that first used it, see resolveLaterTimeZones.
+	timeZones       map[string]model.TimeZone
+	locations       map[string]*time.Location
+	unresolvedTZIDs map[string]*ParseError
+
+	// emit receives the top-level components instead of the calendar, when set. See Decoder.
+	emit func(model.TopLevelComponent)
+
+	// lenient makes problems get collected in diagnostics instead of stopping the parse.
+	lenient     bool
+	diagnostics []*ParseError
+
+	// raw and propertyName describe the content line being parsed, for error reporting.
+	raw          string
+	propertyName string
+
+	// stack holds the components that have been opened by a BEGIN line and not yet closed, innermost last.
+	stack []frame
+
+	// Typed components that are being built. A typed component can never contain another component of the same type,
+	// so one value of each type is enough. Each is reset on BEGIN and attached to its parent on END.
+	event      model.Event
+	todo       model.Todo
+	journal    model.Journal
+	freeBusy   model.FreeBusy
+	timeZone   model.TimeZone
+	observance model.TimeZoneProperty
+	alarm      model.Alarm
+
+	// others holds the open generic components, innermost last.
+	others []model.Component
+}
+
+// text decodes a TEXT property value unless raw text was requested.
+func (p *parser) text(value string) string {
+	if p.options.rawText {
+		return value
+	}
+	return model.UnescapeText(value)
+}
+
+// textList splits a comma separated TEXT list property value.
+func (p *parser) textList(value string) []string {
+	return splitTextList(value, !p.options.rawText)
+}
 
 // IcalFromFileName parses an iCalendar file from the given file path into a Calendar.
 // It opens the file, parses its contents, and returns a Calendar.
@@ -50,7 +101,7 @@ func IcalFromFileName(filename string) (*model.Calendar, error) {
 func IcalString(input string) (*model.Calendar, error) {
 	// Handle empty input
 	if input == "" {
-		return nil, errNoCalendarFound
+		return nil, ErrNoCalendarFound
 	}
 
 	// Use the reader-based parser for consistency
@@ -59,202 +110,304 @@ func IcalString(input string) (*model.Calendar, error) {
 }
 
 // IcalReader takes an io.Reader containing iCalendar data and parses it into a Calendar.
+// Folded content lines are unfolded before they are parsed, and both CRLF and LF line endings are accepted.
+// TEXT values are unescaped, so a DESCRIPTION of `a\, b` is returned as `a, b`.
+// Errors in the input are returned as a *ParseError wrapping one of the Err sentinels of this package,
+// except for input without any content line, which returns ErrNoCalendarFound.
 func IcalReader(reader io.Reader) (*model.Calendar, error) {
-	calendar := &model.Calendar{}
-	currentState := stateCalendar
-	// Reusable parameter map to avoid allocations on every property
-	reusableParams := make(map[string]string, 2)
-	scanner := bufio.NewScanner(reader)
+	return IcalReaderWithOptions(reader)
+}
 
-	if !scanner.Scan() {
-		return nil, errNoCalendarFound
-	}
+// IcalReaderWithOptions parses iCalendar data like IcalReader, with its behavior adjusted by opts.
+func IcalReaderWithOptions(reader io.Reader, opts ...Option) (*model.Calendar, error) {
+	p := newParser(opts)
+	return p.parse(reader)
+}
 
-	line := strings.TrimRight(scanner.Text(), " ")
-	if line != "BEGIN:VCALENDAR" {
-		return nil, errInvalidCalendarFormatMissingBegin
+// IcalReaderAll parses an iCalendar stream that holds one or more VCALENDAR objects one after another,
+// as CalDAV and iMIP bundles often do, and returns them in input order.
+// Each calendar is parsed and validated exactly as IcalReaderWithOptions would, and a TZID only refers to
+// the VTIMEZONE components of its own calendar. The Limits apply to the stream as a whole, not to each calendar.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.4
+func IcalReaderAll(reader io.Reader, opts ...Option) ([]*model.Calendar, error) {
+	p := newParser(opts)
+	p.multiple = true
+	calendar, err := p.parse(reader)
+	if err != nil {
+		return nil, err
 	}
+	return append(p.calendars, calendar), nil
+}
 
-	for scanner.Scan() {
-		line := strings.TrimRight(scanner.Text(), " ")
-
-		if line == "" {
-			return nil, errInvalidCalendarEmptyLine
-		}
+// IcalReaderLenient parses iCalendar data like IcalReaderWithOptions, but does not stop at the first problem.
+// A property that cannot be parsed is skipped, a component that is misplaced or lacks a required property is dropped,
+// and components left open at the end of the input are closed.
+// It returns the calendar built from everything that could be parsed, together with every problem found, in input order.
+// The calendar is nil only when the input does not start with BEGIN:VCALENDAR, in which case the last problem is
+// of SeverityFatal.
+func IcalReaderLenient(reader io.Reader, opts ...Option) (*model.Calendar, []*ParseError) {
+	p := newParser(opts)
+	p.lenient = true
+	calendar, _ := p.parse(reader)
+	return calendar, p.diagnostics
+}
 
-		// Clear the reusable parameter map before each use
-		for k := range reusableParams {
-			delete(reusableParams, k)
-		}
+// newParser returns a parser configured by opts.
+func newParser(opts []Option) *parser {
+	p := &parser{
+		calendar: &model.Calendar{},
+		// Reusable parameter map to avoid allocations on every property
+		params: make(model.Parameters, 2),
+	}
+	for _, opt := range opts {
+		opt(&p.options)
+	}
+	return p
+}
 
-		propertyName, params, value, err := parseIcalLineWithReusableMap(line, reusableParams)
+// parse reads a calendar from reader.
+// In lenient mode problems are collected in p.diagnostics, and an error is only returned together with a nil calendar.
+func (p *parser) parse(reader io.Reader) (*model.Calendar, error) {
+	p.lines = newLineReader(reader, p.options.limits)
+	if err := p.start(); err != nil {
+		return nil, err
+	}
+	for {
+		done, err := p.step()
 		if err != nil {
 			return nil, err
 		}
-		switch propertyName {
-		case "BEGIN":
-			if err := handleBeginBlock(value, &currentState, calendar); err != nil {
-				return nil, err
-			}
-			continue
-		case "END":
-			if currentState == stateFinished {
-				return nil, errContentAfterEndBlock
-			}
-			if err := handleEndBlock(value, &currentState, calendar); err != nil {
-				return nil, err
-			}
-			continue
-		default:
-			if currentState == stateFinished {
-				return nil, errContentAfterEndBlock
-			}
-			if err := parsePropertyLine(propertyName, value, params, currentState, calendar); err != nil {
-				return nil, err
-			}
-			continue
+		if done {
+			break
 		}
 	}
-
-	// Check for scanner errors
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading iCalendar data: %w", err)
+	if err := p.finish(); err != nil {
+		return nil, err
 	}
+	return p.calendar, nil
+}
 
-	// Verify that the last line was a END:VCALENDAR
-	if currentState != stateFinished {
-		return nil, errInvalidCalendarFormatMissingEnd
+// start reads the BEGIN:VCALENDAR line that must open the input.
+func (p *parser) start() error {
+	line, err := p.lines.next()
+	if errors.Is(err, io.EOF) {
+		if p.lenient {
+			return p.fatal(ErrNoCalendarFound)
+		}
+		return ErrNoCalendarFound
+	}
+	if err != nil {
+		return p.fatal(fmt.Errorf("error reading iCalendar data: %w", err))
 	}
 
-	return calendar, nil
+	if p.options.skipByteOrderMark {
+		line = strings.TrimPrefix(line, byteOrderMark)
+	}
+	p.raw = p.trimLine(line)
+	if !strings.EqualFold(p.raw, "BEGIN:VCALENDAR") {
+		return p.fatal(ErrInvalidCalendarFormatMissingBegin)
+	}
+	p.push(kindCalendar, string(model.SectionTokenVCalendar), 0)
+	return nil
 }
 
-// parsePropertyLine parses a single property line and adds it to the appropriate component based on current state.
-func parsePropertyLine(propertyName string, value string, params map[string]string, currentState parserState, calendar *model.Calendar) error {
-	// Route to appropriate parser based on current state
-	switch currentState {
-	case stateEventAlarm:
-		currentAlarm := &calendar.Events[len(calendar.Events)-1].Alarms[len(calendar.Events[len(calendar.Events)-1].Alarms)-1]
-		return parseAlarmProperty(propertyName, value, params, currentAlarm)
-	case stateTodoAlarm:
-		currentAlarm := &calendar.Todos[len(calendar.Todos)-1].Alarms[len(calendar.Todos[len(calendar.Todos)-1].Alarms)-1]
-		return parseAlarmProperty(propertyName, value, params, currentAlarm)
-	case stateEvent:
-		return parseEventProperty(propertyName, value, params, &calendar.Events[len(calendar.Events)-1])
-	case stateTimezone:
-		return parseTimezoneProperty(propertyName, value, params, currentState, &calendar.TimeZones[len(calendar.TimeZones)-1])
-	case stateTodo:
-		return parseTodoProperty(propertyName, value, params, &calendar.Todos[len(calendar.Todos)-1])
-	case stateJournal:
-		return parseJournalProperty(propertyName, value, params, &calendar.Journals[len(calendar.Journals)-1])
-	case stateFreebusy:
-		return parseFreeBusyProperty(propertyName, value, params, &calendar.FreeBusys[len(calendar.FreeBusys)-1])
-	case stateStandard, stateDaylight:
-		// These are handled within timezone parsing
-		return parseTimezoneProperty(propertyName, value, params, currentState, &calendar.TimeZones[len(calendar.TimeZones)-1])
-	default: // StateCalendar
-		return parseCalendarProperty(propertyName, value, params, calendar)
+// step reads and handles the next content line. It reports done once there is nothing more to read.
+func (p *parser) step() (done bool, err error) {
+	line, err := p.lines.next()
+	p.raw, p.propertyName = "", ""
+	if errors.Is(err, io.EOF) {
+		return true, nil
 	}
-}
+	if errors.Is(err, ErrLimitExceeded) {
+		return true, p.report(SeverityFatal, "", err)
+	}
+	if err != nil {
+		return true, p.report(SeverityFatal, "", fmt.Errorf("error reading iCalendar data: %w", err))
+	}
+	p.raw = p.trimLine(line)
 
-// handleBeginBlock processes BEGIN blocks and updates the parser state.
-func handleBeginBlock(beginValue string, currentState *parserState, calendar *model.Calendar) error {
-	switch beginValue {
-	case string(model.SectionTokenVEvent):
-		*currentState = stateEvent
-		calendar.Events = append(calendar.Events, model.Event{})
-	case string(model.SectionTokenVCalendar):
-		*currentState = stateCalendar
-	case string(model.SectionTokenVTimezone):
-		*currentState = stateTimezone
-		calendar.TimeZones = append(calendar.TimeZones, model.TimeZone{})
-	case string(model.SectionTokenVFreebusy):
-		*currentState = stateFreebusy
-		calendar.FreeBusys = append(calendar.FreeBusys, model.FreeBusy{})
-	case string(model.SectionTokenVAlarm):
-		// Determine which parent component to add the alarm to based on current state
-		switch *currentState {
-		case stateEvent:
-			*currentState = stateEventAlarm
-			calendar.Events[len(calendar.Events)-1].Alarms = append(calendar.Events[len(calendar.Events)-1].Alarms, model.Alarm{})
-		case stateTodo:
-			*currentState = stateTodoAlarm
-			calendar.Todos[len(calendar.Todos)-1].Alarms = append(calendar.Todos[len(calendar.Todos)-1].Alarms, model.Alarm{})
-		case stateJournal:
-			// Journal alarms are not supported in the current model, but we'll handle gracefully
-			calendar.Journals[len(calendar.Journals)-1].Alarms = append(calendar.Journals[len(calendar.Journals)-1].Alarms, model.Alarm{})
+	if p.raw == "" {
+		if p.options.allowBlankLines {
+			return false, nil
 		}
-	case string(model.SectionTokenVJournal):
-		*currentState = stateJournal
-		calendar.Journals = append(calendar.Journals, model.Journal{})
-	case string(model.SectionTokenVTodo):
-		*currentState = stateTodo
-		calendar.Todos = append(calendar.Todos, model.Todo{})
-	case string(model.SectionTokenVStandard):
-		*currentState = stateStandard
-		calendar.TimeZones[len(calendar.TimeZones)-1].Standard = append(calendar.TimeZones[len(calendar.TimeZones)-1].Standard, model.TimeZoneProperty{})
-	case string(model.SectionTokenVDaylight):
-		*currentState = stateDaylight
-		calendar.TimeZones[len(calendar.TimeZones)-1].Daylight = append(calendar.TimeZones[len(calendar.TimeZones)-1].Daylight, model.TimeZoneProperty{})
-	default:
-		return fmt.Errorf("%w: %s", errTemplateInvalidStartBlock, beginValue)
+		return false, p.report(SeverityWarning, "", ErrInvalidCalendarEmptyLine)
 	}
-	return nil
-}
 
-// handleEndBlock processes END blocks and updates the parser state.
-func handleEndBlock(endLineValue string, currentState *parserState, calendar *model.Calendar) error {
-	switch endLineValue {
-	case string(model.SectionTokenVEvent):
-		if err := validateEvent(calendar.Events[len(calendar.Events)-1]); err != nil {
-			return err
-		}
-		*currentState = stateCalendar
-	case string(model.SectionTokenVCalendar):
-		if err := validateCalendar(calendar); err != nil {
-			return err
+	// Clear the reusable parameter map before each use
+	clear(p.params)
+
+	propertyName, params, value, err := parseIcalLineWithReusableMap(p.raw, p.params)
+	if err != nil {
+		return false, p.report(SeverityError, "", err)
+	}
+	// Property and component names are case-insensitive.
+	propertyName = upperASCII(propertyName)
+	if propertyName == "BEGIN" || propertyName == "END" {
+		value = upperASCII(value)
+	}
+	p.propertyName = propertyName
+
+	// Nothing but another VCALENDAR may follow the END:VCALENDAR line.
+	if len(p.stack) == 0 {
+		if p.multiple && propertyName == "BEGIN" && value == string(model.SectionTokenVCalendar) {
+			p.calendars = append(p.calendars, p.calendar)
+			p.resetCalendar()
+			p.push(kindCalendar, value, len(p.calendars))
+			return false, nil
 		}
-		*currentState = stateFinished
-	case string(model.SectionTokenVTimezone):
-		if err := validateTimeZone(&calendar.TimeZones[len(calendar.TimeZones)-1]); err != nil {
-			return err
+		return true, p.report(SeverityError, "", ErrContentAfterEndBlock)
+	}
+	if err := p.checkLimits(propertyName, params); err != nil {
+		return true, p.report(SeverityFatal, "", err)
+	}
+	switch propertyName {
+	case "BEGIN":
+		return false, p.handleBeginBlock(value)
+	case "END":
+		return false, p.handleEndBlock(value)
+	default:
+		if err := p.parsePropertyLine(propertyName, value, params); err != nil {
+			return false, p.report(SeverityError, value, err)
 		}
-		*currentState = stateCalendar
-	case string(model.SectionTokenVFreebusy):
-		if err := validateFreeBusy(&calendar.FreeBusys[len(calendar.FreeBusys)-1]); err != nil {
-			return err
+		return false, nil
+	}
+}
+
+// resetCalendar starts a new calendar, forgetting the time zones of the previous one.
+// The resource limits keep counting, as they cover the whole input.
+func (p *parser) resetCalendar() {
+	p.calendar = &model.Calendar{}
+	p.timeZones = nil
+	p.locations = nil
+	p.unresolvedTZIDs = nil
+}
+
+// checkLimits counts the content line about to be handled against the limits that are not enforced by the lineReader.
+func (p *parser) checkLimits(propertyName string, params model.Parameters) error {
+	limits := &p.options.limits
+	if limits.MaxParameterValues > 0 && countParameterValues(params) > limits.MaxParameterValues {
+		return fmt.Errorf("%w: more than MaxParameterValues of %d parameter values", ErrLimitExceeded, limits.MaxParameterValues)
+	}
+	switch propertyName {
+	case "BEGIN":
+		p.components++
+		if limits.MaxComponents > 0 && p.components > limits.MaxComponents {
+			return fmt.Errorf("%w: more than MaxComponents of %d components", ErrLimitExceeded, limits.MaxComponents)
 		}
-		*currentState = stateCalendar
-	case string(model.SectionTokenVAlarm):
-		// Validate alarm based on current state
-		switch *currentState {
-		case stateEventAlarm:
-			if err := validateAlarm(&calendar.Events[len(calendar.Events)-1].Alarms[len(calendar.Events[len(calendar.Events)-1].Alarms)-1]); err != nil {
-				return err
-			}
-			*currentState = stateEvent // Return to parent state
-		case stateTodoAlarm:
-			if err := validateAlarm(&calendar.Todos[len(calendar.Todos)-1].Alarms[len(calendar.Todos[len(calendar.Todos)-1].Alarms)-1]); err != nil {
-				return err
-			}
-			*currentState = stateTodo // Return to parent state
+	case "END":
+	default:
+		current := &p.stack[len(p.stack)-1]
+		current.properties++
+		if limits.MaxPropertiesPerComponent > 0 && current.properties > limits.MaxPropertiesPerComponent {
+			return fmt.Errorf("%w: more than MaxPropertiesPerComponent of %d properties", ErrLimitExceeded, limits.MaxPropertiesPerComponent)
 		}
-	case string(model.SectionTokenVJournal):
-		if err := validateJournal(&calendar.Journals[len(calendar.Journals)-1]); err != nil {
+	}
+	return nil
+}
+
+// countParameterValues returns the number of values of all parameters of a content line.
+func countParameterValues(params model.Parameters) int {
+	count := 0
+	for _, values := range params {
+		count += len(values)
+	}
+	return count
+}
+
+// finish checks that every component, including the VCALENDAR itself, was closed once the input is exhausted.
+func (p *parser) finish() error {
+	if len(p.stack) == 0 {
+		return nil
+	}
+	if !p.options.allowMissingEnd {
+		if err := p.report(SeverityWarning, "", ErrInvalidCalendarFormatMissingEnd); err != nil {
 			return err
 		}
-		*currentState = stateCalendar
-	case string(model.SectionTokenVTodo):
-		if err := validateTodo(&calendar.Todos[len(calendar.Todos)-1]); err != nil {
+	}
+	for len(p.stack) != 0 {
+		if err := p.closeComponent(); err != nil {
 			return err
 		}
-		*currentState = stateCalendar
-	case string(model.SectionTokenVStandard):
-		*currentState = stateTimezone
-	case string(model.SectionTokenVDaylight):
-		*currentState = stateTimezone
-	default:
-		return fmt.Errorf("%w: %s", errTemplateInvalidEndBlock, endLineValue)
 	}
 	return nil
 }
+
+// trimLine removes the spaces at the end of a content line, unless they should be kept.
+func (p *parser) trimLine(line string) string {
+	if p.options.keepTrailingSpaces {
+		return line
+	}
+	return strings.TrimRight(line, " ")
+}
+
+// report handles a problem found on the current line.
+// value is the property value the problem is about, if any, and is used to compute the column.
+// In strict mode the problem is returned as a *ParseError, which stops the parse.
+// In lenient mode it is recorded with the given severity and nil is returned, so that the parse can continue.
+func (p *parser) report(severity Severity, value string, err error) error {
+	parseError := p.locate(value)
+	parseError.Severity = severity
+	parseError.Err = err
+	return p.record(parseError)
+}
+
+// locate returns a ParseError that locates the current line, without a severity or an error.
+// value is the property value the problem is about, if any, and is used to compute the column.
+func (p *parser) locate(value string) *ParseError {
+	parseError := &ParseError{
+		Line:     p.lines.line,
+		Column:   1,
+		Offset:   p.lines.lineOffset,
+		Path:     p.path(),
+		Property: p.propertyName,
+		Raw:      p.raw,
+	}
+	if value != "" {
+		// value is always a suffix of the raw line.
+		parseError.Column = len(p.raw) - len(value) + 1
+	}
+	return parseError
+}
+
+// record handles a located problem like report does.
+func (p *parser) record(parseError *ParseError) error {
+	if !p.lenient {
+		return parseError
+	}
+	p.diagnostics = append(p.diagnostics, parseError)
+	return nil
+}
+
+// fatal handles a problem that stops the parse even in lenient mode.
+func (p *parser) fatal(err error) error {
+	if reportErr := p.report(SeverityFatal, "", err); reportErr != nil {
+		return reportErr
+	}
+	return p.diagnostics[len(p.diagnostics)-1]
+}
+
+// parsePropertyLine parses a single property line and adds it to the innermost open component.
+func (p *parser) parsePropertyLine(propertyName string, value string, params model.Parameters) error {
+	switch p.stack[len(p.stack)-1].kind {
+	case kindEvent:
+		return p.parseEventProperty(propertyName, value, params, &p.event)
+	case kindTodo:
+		return p.parseTodoProperty(propertyName, value, params, &p.todo)
+	case kindJournal:
+		return p.parseJournalProperty(propertyName, value, params, &p.journal)
+	case kindFreeBusy:
+		return p.parseFreeBusyProperty(propertyName, value, params, &p.freeBusy)
+	case kindTimeZone:
+		return p.parseTimezoneProperty(propertyName, value, params, &p.timeZone)
+	case kindStandard, kindDaylight:
+		return p.parseTimeZonePropertySubComponent(propertyName, value, params, &p.observance)
+	case kindAlarm:
+		return p.parseAlarmProperty(propertyName, value, params, &p.alarm)
+	case kindOther:
+		return p.parseOtherComponentProperty(propertyName, value, params)
+	case kindSkipped:
+		return nil
+	default: // kindCalendar
+		return p.parseCalendarProperty(propertyName, value, params, p.calendar)
+	}
+}
@@ -2,30 +2,98 @@ package parse
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/michael-gallo/simpleical/icaldur"
+	"github.com/michael-gallo/simpleical/model"
 )
 
 // setOnceProperty ensures that set-once properties have consistent error handling
 func setOnceProperty[T comparable](field *T, value T, propertyName string, componentType string) error {
 	var zero T
 	if *field != zero {
-		return fmt.Errorf(errDuplicatePropertyInComponentFormat, errDuplicatePropertyInComponent, propertyName, componentType)
+		return fmt.Errorf(errDuplicatePropertyInComponentFormat, ErrDuplicatePropertyInComponent, propertyName, componentType)
 	}
 	*field = value
 	return nil
 }
 
+// setOnceGeoProperty parses a GEO value, a latitude and a longitude separated by a semicolon,
+// and sets field only if it hasn't been set before.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.6
+func setOnceGeoProperty(field **model.Geo, value, propertyName string, componentType string) error {
+	if *field != nil {
+		return fmt.Errorf(errDuplicatePropertyInComponentFormat, ErrDuplicatePropertyInComponent, propertyName, componentType)
+	}
+	latitudeString, longitudeString, found := strings.Cut(value, ";")
+	if !found {
+		return ErrInvalidGeoProperty
+	}
+	latitude, err := strconv.ParseFloat(latitudeString, 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return fmt.Errorf("%w: %s", ErrInvalidGeoPropertyLatitude, latitudeString)
+	}
+	longitude, err := strconv.ParseFloat(longitudeString, 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return fmt.Errorf("%w: %s", ErrInvalidGeoPropertyLongitude, longitudeString)
+	}
+	*field = &model.Geo{Lat: latitude, Lon: longitude}
+	return nil
+}
+
 // setOnceIntProperty sets an int field only if it hasn't been set before.
 // this is intended for properties that according to the spec must only be set once
-func setOnceIntProperty(field *int, value, propertyName string, componentType string) error {
+// 0 is a valid value, eg: SEQUENCE:0, so the properties already read are tracked on the open component.
+func (p *parser) setOnceIntProperty(field *int, value, propertyName string, componentType string) error {
 	intValue, err := strconv.Atoi(value)
 	if err != nil {
-		return fmt.Errorf("%w: %s property %s in iCal", errParseErrorInComponent, componentType, propertyName)
+		return fmt.Errorf("%w: %s property %s in iCal", ErrParseErrorInComponent, componentType, propertyName)
+	}
+	if err := p.markSet(propertyName, componentType); err != nil {
+		return err
+	}
+	*field = intValue
+	return nil
+}
+
+// setOnceUTCOffsetProperty sets a UTC-OFFSET field, such as TZOFFSETFROM, only if it hasn't been set before.
+// +0000 is a valid value, so like INTEGER properties the properties already read are tracked on the open component.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.14
+func (p *parser) setOnceUTCOffsetProperty(field *model.UTCOffset, value, propertyName string, componentType string) error {
+	offset, err := model.ParseUTCOffset(value)
+	if err != nil {
+		return fmt.Errorf("%w: %s %s", ErrInvalidPropertyValue, propertyName, value)
+	}
+	if err := p.markSet(propertyName, componentType); err != nil {
+		return err
 	}
-	return setOnceProperty(field, intValue, propertyName, componentType)
+	*field = offset
+	return nil
+}
+
+// markSet records that a set-once property whose zero value is valid has been read on the open component.
+// It fails if the property has been read before.
+func (p *parser) markSet(propertyName string, componentType string) error {
+	current := &p.stack[len(p.stack)-1]
+	if slices.Contains(current.setProperties, propertyName) {
+		return fmt.Errorf(errDuplicatePropertyInComponentFormat, ErrDuplicatePropertyInComponent, propertyName, componentType)
+	}
+	current.setProperties = append(current.setProperties, propertyName)
+	return nil
+}
+
+// setOncePriorityProperty sets the PRIORITY field only if it hasn't been set before.
+// A priority is an integer from 0, undefined, to 9, the lowest.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.1.9
+func (p *parser) setOncePriorityProperty(field *int, value, propertyName string, componentType string) error {
+	if priority, err := strconv.Atoi(value); err == nil && (priority < 0 || priority > 9) {
+		return fmt.Errorf("%w: %s %s", ErrInvalidPropertyValue, propertyName, value)
+	}
+	return p.setOnceIntProperty(field, value, propertyName, componentType)
 }
 
 // setOnceTimeProperty sets a time.Time field only if it hasn't been set before.
@@ -33,7 +101,7 @@ func setOnceIntProperty(field *int, value, propertyName string, componentType st
 func setOnceTimeProperty(field *time.Time, value, propertyName string, componentType string) error {
 	time, err := icaldur.ParseIcalTime(value)
 	if err != nil {
-		return fmt.Errorf("%w: %s property %s in iCal", errParseErrorInComponent, componentType, propertyName)
+		return fmt.Errorf("%w: %s property %s in iCal", ErrParseErrorInComponent, componentType, propertyName)
 	}
 	return setOnceProperty(field, time, propertyName, componentType)
 }
@@ -43,16 +111,136 @@ func setOnceTimeProperty(field *time.Time, value, propertyName string, component
 func setOnceDurationProperty(field *time.Duration, value, propertyName string, componentType string) error {
 	duration, err := icaldur.ParseICalDuration(value)
 	if err != nil {
-		return fmt.Errorf("%w: %s property %s in iCal", errParseErrorInComponent, componentType, propertyName)
+		return fmt.Errorf("%w: %s property %s in iCal", ErrParseErrorInComponent, componentType, propertyName)
 	}
 	return setOnceProperty(field, duration, propertyName, componentType)
 }
 
-func appendTimeProperty(field *[]time.Time, value, propertyName string, componentType string) error {
-	time, err := icaldur.ParseIcalTime(value)
+// setOnceDateTimeProperty sets a DATE or DATE-TIME field only if it hasn't been set before.
+// this is intended for properties that according to the spec must only be set once
+func (p *parser) setOnceDateTimeProperty(field *model.DateTime, value string, params model.Parameters, propertyName string, componentType string) error {
+	dateTime, err := p.parseDateTime(value, params, propertyName, componentType)
 	if err != nil {
-		return fmt.Errorf("%w: %s property %s in iCal", errParseErrorInComponent, componentType, propertyName)
+		return err
 	}
-	*field = append(*field, time)
+	return setOnceProperty(field, dateTime, propertyName, componentType)
+}
+
+// appendDateTimeProperty appends the comma separated DATE or DATE-TIME values of a repeatable property such as EXDATE.
+func (p *parser) appendDateTimeProperty(field *[]model.DateTime, value string, params model.Parameters, propertyName string, componentType string) error {
+	dateTimes := *field
+	for dateValue := range strings.SplitSeq(value, ",") {
+		dateTime, err := p.parseDateTime(dateValue, params, propertyName, componentType)
+		if err != nil {
+			return err
+		}
+		dateTimes = append(dateTimes, dateTime)
+	}
+	*field = dateTimes
 	return nil
 }
+
+// appendRdateProperty appends the values of an RDATE property like appendDateTimeProperty.
+// The model has no field for periods, so an RDATE;VALUE=PERIOD is kept as it was read with the IANA properties.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.2
+func (p *parser) appendRdateProperty(field *[]model.DateTime, ianaProps *map[string][]model.Property, value string, params model.Parameters, propertyName string, componentType string) error {
+	if strings.EqualFold(params.Get("VALUE"), "PERIOD") {
+		appendProperty(ianaProps, propertyName, value, params)
+		return nil
+	}
+	return p.appendDateTimeProperty(field, value, params, propertyName, componentType)
+}
+
+// textProperty decodes a TEXT property value together with its LANGUAGE and ALTREP parameters.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.11
+func (p *parser) textProperty(value string, params model.Parameters, propertyName string) (model.Text, error) {
+	text := model.Text{Value: p.text(value), Language: params.Get("LANGUAGE")}
+	if altRep := params.Get("ALTREP"); altRep != "" {
+		uri, err := parseURI(altRep, propertyName+" ALTREP")
+		if err != nil {
+			return model.Text{}, err
+		}
+		text.AltRep = uri
+	}
+	return text, nil
+}
+
+// setOnceTextProperty sets a TEXT field only if it hasn't been set before.
+// this is intended for properties that according to the spec must only be set once
+func (p *parser) setOnceTextProperty(field *model.Text, value string, params model.Parameters, propertyName string, componentType string) error {
+	text, err := p.textProperty(value, params, propertyName)
+	if err != nil {
+		return err
+	}
+	return setOnceProperty(field, text, propertyName, componentType)
+}
+
+// appendTextProperty appends the value of a repeatable TEXT property such as COMMENT.
+func (p *parser) appendTextProperty(field *[]model.Text, value string, params model.Parameters, propertyName string) error {
+	text, err := p.textProperty(value, params, propertyName)
+	if err != nil {
+		return err
+	}
+	*field = append(*field, text)
+	return nil
+}
+
+// appendTextListProperty appends the comma separated values of a repeatable TEXT list property such as RESOURCES.
+// Every value of the line shares its LANGUAGE and ALTREP parameters.
+func (p *parser) appendTextListProperty(field *[]model.Text, value string, params model.Parameters, propertyName string) error {
+	text, err := p.textProperty("", params, propertyName)
+	if err != nil {
+		return err
+	}
+	for _, listValue := range p.textList(value) {
+		text.Value = listValue
+		*field = append(*field, text)
+	}
+	return nil
+}
+
+// appendExtraProperty stores a property that has no dedicated field in the XProp or IANAProp map of a component.
+// Names starting with X- are non-standard properties, any other valid name is kept as an IANA property.
+// The property may occur more than once. invalidErr is returned when the name is not a valid property name,
+// or when it is an IANA property and unknown properties are rejected.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8
+func (p *parser) appendExtraProperty(xProps, ianaProps *map[string][]model.Property, propertyName, value string, params model.Parameters, invalidErr error) error {
+	if !isValidPropertyName(propertyName) {
+		return fmt.Errorf("%w: %s", invalidErr, propertyName)
+	}
+	target := ianaProps
+	if strings.HasPrefix(propertyName, "X-") {
+		target = xProps
+	} else if p.options.rejectUnknownProperties {
+		return fmt.Errorf("%w: %s", invalidErr, propertyName)
+	}
+	appendProperty(target, propertyName, value, params)
+	return nil
+}
+
+// appendProperty keeps a property as it was read in a map of properties by name.
+func appendProperty(target *map[string][]model.Property, propertyName, value string, params model.Parameters) {
+	if *target == nil {
+		*target = make(map[string][]model.Property, 1)
+	}
+	// The parameter map is reused between lines, so it must be copied before it is kept.
+	(*target)[propertyName] = append((*target)[propertyName], model.Property{
+		Name:   propertyName,
+		Params: maps.Clone(params),
+		Value:  value,
+	})
+}
+
+// classes are the CLASS values RFC 5545 defines for VEVENT, VTODO and VJOURNAL. The property can be extended.
+var classes = []model.Class{model.ClassPublic, model.ClassPrivate, model.ClassConfidential}
+
+// enumValue converts the value of an enumerated property to its model type.
+// Enumerated values are case-insensitive, so the value is upper-cased.
+// With strict enums the value must be one of allowed, or an X- name if the property is extensible.
+func enumValue[T ~string](o *options, value, propertyName string, allowed []T, extensible bool) (T, error) {
+	enum := T(upperASCII(value))
+	if !o.strictEnums || slices.Contains(allowed, enum) || (extensible && strings.HasPrefix(string(enum), "X-")) {
+		return enum, nil
+	}
+	return "", fmt.Errorf("%w: %s %s", ErrInvalidPropertyValue, propertyName, value)
+}
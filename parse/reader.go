@@ -0,0 +1,128 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// lineReader reads logical content lines from an iCalendar stream.
+// Long content lines are split over several physical lines ("folded") by inserting a line break
+// followed by a single space or horizontal tab; lineReader removes that sequence again ("unfolding").
+// Both CRLF and bare LF line endings are accepted.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.1
+type lineReader struct {
+	reader *bufio.Reader
+	// buf is reused between calls to avoid allocating a buffer for every line.
+	buf []byte
+
+	// maxLineLength caps the length of a logical content line, and maxBytes the length of the whole input.
+	// Zero means unlimited.
+	maxLineLength int
+	maxBytes      int64
+
+	// lines and offset count the physical lines and bytes consumed so far.
+	lines  int
+	offset int64
+	// line and lineOffset locate the first physical line of the content line last returned by next.
+	line       int
+	lineOffset int64
+}
+
+// newLineReader returns a lineReader reading from reader, enforcing the line and input length caps of limits.
+func newLineReader(reader io.Reader, limits Limits) *lineReader {
+	if limits.MaxBytes > 0 {
+		// Reading one byte past the cap is enough to detect that it was exceeded.
+		reader = io.LimitReader(reader, limits.MaxBytes+1)
+	}
+	return &lineReader{
+		reader:        bufio.NewReader(reader),
+		buf:           make([]byte, 0, 256),
+		maxLineLength: limits.MaxLineLength,
+		maxBytes:      limits.MaxBytes,
+	}
+}
+
+// next returns the next unfolded content line without its line ending.
+// It returns io.EOF once the input is exhausted.
+// Folding operates on octets, so multi-octet UTF-8 sequences split across a fold are joined back together.
+func (l *lineReader) next() (string, error) {
+	l.buf = l.buf[:0]
+	l.line = l.lines + 1
+	l.lineOffset = l.offset
+	readAny := false
+	for {
+		n, err := l.readPhysicalLine()
+		if n > 0 {
+			readAny = true
+			l.lines++
+			l.offset += int64(n)
+			if l.maxBytes > 0 && l.offset > l.maxBytes {
+				return "", fmt.Errorf("%w: input longer than MaxBytes of %d", ErrLimitExceeded, l.maxBytes)
+			}
+		}
+		if err == io.EOF {
+			if !readAny {
+				return "", io.EOF
+			}
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		// A line starting with a space or tab continues the previous line.
+		peeked, err := l.reader.Peek(1)
+		if err != nil || (peeked[0] != ' ' && peeked[0] != '\t') {
+			break
+		}
+		_, _ = l.reader.Discard(1)
+		l.offset++
+	}
+	return string(l.buf), nil
+}
+
+// readPhysicalLine appends the next physical line to l.buf, stripping its line ending.
+// It returns the number of bytes consumed from the underlying reader.
+// io.EOF is only returned when no bytes at all could be read.
+func (l *lineReader) readPhysicalLine() (int, error) {
+	consumed := 0
+	for {
+		chunk, err := l.reader.ReadSlice('\n')
+		consumed += len(chunk)
+		l.buf = append(l.buf, chunk...)
+		if l.maxLineLength > 0 && len(l.buf) > l.maxLineLength {
+			return consumed, fmt.Errorf("%w: content line longer than MaxLineLength of %d", ErrLimitExceeded, l.maxLineLength)
+		}
+		switch {
+		case err == nil:
+			l.trimLineEnding()
+			return consumed, nil
+		case errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case errors.Is(err, io.EOF):
+			if consumed == 0 {
+				return 0, io.EOF
+			}
+			l.trimLineEnding()
+			return consumed, nil
+		default:
+			return consumed, err
+		}
+	}
+}
+
+// trimLineEnding removes a trailing LF or CRLF from l.buf.
+func (l *lineReader) trimLineEnding() {
+	if n := len(l.buf); n > 0 && l.buf[n-1] == '\n' {
+		l.buf = l.buf[:n-1]
+	}
+	if n := len(l.buf); n > 0 && l.buf[n-1] == '\r' {
+		l.buf = l.buf[:n-1]
+	}
+}
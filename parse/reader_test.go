@@ -0,0 +1,140 @@
+package parse
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineReader(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedLines []string
+	}{
+		{
+			name:          "CRLF line endings",
+			input:         "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+			expectedLines: []string{"BEGIN:VCALENDAR", "VERSION:2.0"},
+		},
+		{
+			name:          "LF line endings and no final newline",
+			input:         "BEGIN:VCALENDAR\nVERSION:2.0",
+			expectedLines: []string{"BEGIN:VCALENDAR", "VERSION:2.0"},
+		},
+		{
+			name:          "Folded with a space",
+			input:         "DESCRIPTION:This is a lo\r\n ng description\r\nSUMMARY:x\r\n",
+			expectedLines: []string{"DESCRIPTION:This is a long description", "SUMMARY:x"},
+		},
+		{
+			name:          "Folded with a tab and bare LF",
+			input:         "DESCRIPTION:This is a lo\n\tng description\n",
+			expectedLines: []string{"DESCRIPTION:This is a long description"},
+		},
+		{
+			name:          "Folded more than once",
+			input:         "SUMMARY:a\r\n b\r\n c\r\n",
+			expectedLines: []string{"SUMMARY:abc"},
+		},
+		{
+			name:          "Only the first whitespace character of a continuation is removed",
+			input:         "SUMMARY:a\r\n  b\r\n",
+			expectedLines: []string{"SUMMARY:a b"},
+		},
+		{
+			name:          "Multi-octet UTF-8 sequence split across a fold",
+			input:         "SUMMARY:caf\xc3\r\n \xa9\r\n",
+			expectedLines: []string{"SUMMARY:café"},
+		},
+		{
+			name:          "Empty line is preserved",
+			input:         "BEGIN:VCALENDAR\r\n\r\nEND:VCALENDAR\r\n",
+			expectedLines: []string{"BEGIN:VCALENDAR", "", "END:VCALENDAR"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			lines := newLineReader(strings.NewReader(testCase.input), Limits{})
+			var got []string
+			for {
+				line, err := lines.next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				assert.NoError(t, err)
+				got = append(got, line)
+			}
+			assert.Equal(t, testCase.expectedLines, got)
+		})
+	}
+}
+
+func TestLineReaderPosition(t *testing.T) {
+	input := "BEGIN:VCALENDAR\r\nDESCRIPTION:a\r\n b\r\nEND:VCALENDAR"
+	lines := newLineReader(strings.NewReader(input), Limits{})
+
+	type position struct {
+		line   int
+		offset int64
+	}
+	var got []position
+	for {
+		_, err := lines.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		got = append(got, position{line: lines.line, offset: lines.lineOffset})
+	}
+	assert.Equal(t, []position{{line: 1, offset: 0}, {line: 2, offset: 17}, {line: 4, offset: 36}}, got)
+}
+
+func TestLineReaderLongLine(t *testing.T) {
+	// Lines longer than the bufio.Reader buffer, and than the 64KB token limit of bufio.Scanner, must be read in full.
+	longValue := strings.Repeat("a", 100000)
+	lines := newLineReader(strings.NewReader("ATTACH:"+longValue+"\r\n"), Limits{})
+	line, err := lines.next()
+	assert.NoError(t, err)
+	assert.Equal(t, "ATTACH:"+longValue, line)
+
+	// A folded line is limited by its unfolded length.
+	lines = newLineReader(strings.NewReader("SUMMARY:aaaa\r\n bbbb\r\n"), Limits{MaxLineLength: 12})
+	_, err = lines.next()
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestLineReaderMaxBytes(t *testing.T) {
+	input := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"
+
+	lines := newLineReader(strings.NewReader(input), Limits{MaxBytes: int64(len(input))})
+	for range 2 {
+		_, err := lines.next()
+		assert.NoError(t, err)
+	}
+	_, err := lines.next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	lines = newLineReader(strings.NewReader(input), Limits{MaxBytes: int64(len(input) - 1)})
+	_, err = lines.next()
+	assert.NoError(t, err)
+	_, err = lines.next()
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func BenchmarkLineReader(b *testing.B) {
+	input := strings.Repeat("DESCRIPTION:This is a long description that has been folded over \r\n more than one line\r\n", 50)
+	var reader strings.Reader
+	for b.Loop() {
+		reader.Reset(input)
+		lines := newLineReader(&reader, Limits{})
+		for {
+			if _, err := lines.next(); err != nil {
+				break
+			}
+		}
+	}
+}
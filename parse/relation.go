@@ -0,0 +1,38 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"github.com/michael-gallo/simpleical/model"
+)
+
+// relationTypes are the RELTYPE values RFC 5545 and RFC 9253 define. The parameter can be extended.
+var relationTypes = []model.RelationType{
+	model.RelationTypeParent, model.RelationTypeChild, model.RelationTypeSibling,
+	model.RelationTypeSnapshot, model.RelationTypeDependsOn, model.RelationTypeRefID, model.RelationTypeConcept,
+	model.RelationTypeFinishToStart, model.RelationTypeFinishToFinish, model.RelationTypeStartToFinish, model.RelationTypeStartToStart,
+	model.RelationTypeFirst, model.RelationTypeNext,
+}
+
+// parseRelation parses a calendar line starting with RELATED-TO.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.4.5
+func (p *parser) parseRelation(value string, params model.Parameters) (model.Relation, error) {
+	relation := model.Relation{UID: p.text(value)}
+	for paramName, paramValues := range params {
+		if paramName != "RELTYPE" {
+			if relation.OtherParams == nil {
+				relation.OtherParams = make(model.Parameters)
+			}
+			relation.OtherParams[paramName] = paramValues
+			continue
+		}
+		relationType, err := enumValue(&p.options, paramValues[0], paramName, relationTypes, true)
+		if err != nil {
+			return model.Relation{}, err
+		}
+		relation.Type = relationType
+	}
+	return relation, nil
+}
@@ -0,0 +1,67 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/michael-gallo/simpleical/model"
+)
+
+// parseRequestStatus parses a calendar line starting with REQUEST-STATUS.
+// The value is a status code, a description and optional extra data, separated by semicolons.
+// Semicolons escaped as `\;` do not separate the parts, and unescaped semicolons after the
+// extra data has started are kept as part of it.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.8.3
+func (p *parser) parseRequestStatus(value string, params model.Parameters) (model.RequestStatus, error) {
+	codeValue, rest, found := cutUnescaped(value, ';')
+	if !found {
+		return model.RequestStatus{}, fmt.Errorf("%w: REQUEST-STATUS %s has no description", ErrInvalidPropertyValue, value)
+	}
+	code, err := parseStatusCode(codeValue)
+	if err != nil {
+		return model.RequestStatus{}, err
+	}
+	description, extraData, _ := cutUnescaped(rest, ';')
+	return model.RequestStatus{
+		Code:        code,
+		Description: p.text(description),
+		ExtraData:   p.text(extraData),
+		Language:    params.Get("LANGUAGE"),
+	}, nil
+}
+
+// parseStatusCode parses the hierarchical status code of a REQUEST-STATUS, two or three
+// numbers separated by periods, eg: 3.7 or 2.8.1.
+func parseStatusCode(value string) ([]int, error) {
+	code := make([]int, 0, 3)
+	for part := range strings.SplitSeq(value, ".") {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 || len(code) == 3 {
+			return nil, fmt.Errorf("%w: REQUEST-STATUS code %s", ErrInvalidPropertyValue, value)
+		}
+		code = append(code, number)
+	}
+	if len(code) < 2 {
+		return nil, fmt.Errorf("%w: REQUEST-STATUS code %s", ErrInvalidPropertyValue, value)
+	}
+	return code, nil
+}
+
+// cutUnescaped slices value around the first separator that is not escaped with a backslash, like strings.Cut.
+func cutUnescaped(value string, separator byte) (before, after string, found bool) {
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			// Skip the escaped character, it can never be a separator.
+			i++
+		case separator:
+			return value[:i], value[i+1:], true
+		}
+	}
+	return value, "", false
+}
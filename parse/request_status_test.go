@@ -0,0 +1,68 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRequestStatus(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		value                 string
+		params                model.Parameters
+		expectedRequestStatus model.RequestStatus
+		expectedError         error
+	}{
+		{
+			name:                  "Code and description",
+			value:                 "2.0;Success",
+			expectedRequestStatus: model.RequestStatus{Code: []int{2, 0}, Description: "Success"},
+		},
+		{
+			name:                  "Three level code with extra data and language",
+			value:                 "3.1.1;Invalid property value;DTSTART:96-Apr-01",
+			params:                model.Parameters{"LANGUAGE": {"en-US"}},
+			expectedRequestStatus: model.RequestStatus{Code: []int{3, 1, 1}, Description: "Invalid property value", ExtraData: "DTSTART:96-Apr-01", Language: "en-US"},
+		},
+		{
+			name:                  "Escaped semicolon in the description",
+			value:                 `4.1;Event conflict\; date-time is busy`,
+			expectedRequestStatus: model.RequestStatus{Code: []int{4, 1}, Description: "Event conflict; date-time is busy"},
+		},
+		{
+			name:          "Missing description",
+			value:         "2.0",
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Code without a period",
+			value:         "2;Success",
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Code with four levels",
+			value:         "2.0.0.0;Success",
+			expectedError: ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Code that is not a number",
+			value:         "2.x;Success",
+			expectedError: ErrInvalidPropertyValue,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			p := &parser{}
+			requestStatus, err := p.parseRequestStatus(testCase.value, testCase.params)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedRequestStatus, requestStatus)
+		})
+	}
+}
@@ -2,65 +2,78 @@ package parse
 
 import (
 	"fmt"
-	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/michael-gallo/simpleical/icaldur"
 	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/rrule"
 )
 
 const timezoneLocation = "TimeZone"
 
 // parseTimezoneProperty parses a single property line and adds it to the provided timezone.
-func parseTimezoneProperty(propertyName string, value string, params map[string]string, currentState parserState, timezone *model.TimeZone) error {
-	// Handle sub-components (STANDARD and DAYLIGHT)
-	if currentState == stateStandard || currentState == stateDaylight {
-		var tzProp *model.TimeZoneProperty
-		if currentState == stateStandard {
-			tzProp = &timezone.Standard[len(timezone.Standard)-1]
-		} else {
-			tzProp = &timezone.Daylight[len(timezone.Daylight)-1]
-		}
-		return parseTimeZonePropertySubComponent(propertyName, value, params, tzProp)
-	}
-
-	// Handle timezone-level properties
+// STANDARD and DAYLIGHT sub-components are handled by parseTimeZonePropertySubComponent.
+func (p *parser) parseTimezoneProperty(propertyName string, value string, params model.Parameters, timezone *model.TimeZone) error {
 	switch model.TimezoneToken(propertyName) {
 	case model.TimezoneTokenTimeZoneID:
-		return setOnceProperty(&timezone.TimeZoneID, value, propertyName, timezoneLocation)
+		return setOnceProperty(&timezone.TimeZoneID, p.text(value), propertyName, timezoneLocation)
 	case model.TimezoneTokenLastMod:
 		return setOnceTimeProperty(&timezone.LastMod, value, propertyName, timezoneLocation)
 	case model.TimezoneTokenTimeZoneURL:
-		parsedURL, err := url.Parse(value)
+		parsedURL, err := parseURI(value, propertyName)
 		if err != nil {
 			return err
 		}
 		return setOnceProperty(&timezone.TimeZoneURL, parsedURL, propertyName, timezoneLocation)
 	default:
-		return fmt.Errorf("%w: %s", errInvalidTimezoneProperty, propertyName)
+		return p.appendExtraProperty(&timezone.XProp, &timezone.IANAProp, propertyName, value, params, ErrInvalidTimezoneProperty)
 	}
 }
 
 // parseTimeZonePropertySubComponent parses a single property line for STANDARD or DAYLIGHT sub-components.
-func parseTimeZonePropertySubComponent(propertyName string, value string, _ map[string]string, tzProp *model.TimeZoneProperty) error {
+func (p *parser) parseTimeZonePropertySubComponent(propertyName string, value string, params model.Parameters, tzProp *model.TimeZoneProperty) error {
 	switch model.TimezoneToken(propertyName) {
 	case model.TimezoneTokenTimeZoneOffsetFrom:
-		tzProp.TimeZoneOffsetFrom = value
+		return p.setOnceUTCOffsetProperty(&tzProp.TimeZoneOffsetFrom, value, propertyName, timezoneLocation)
 	case model.TimezoneTokenTimeZoneOffsetTo:
-		tzProp.TimeZoneOffsetTo = value
+		return p.setOnceUTCOffsetProperty(&tzProp.TimeZoneOffsetTo, value, propertyName, timezoneLocation)
 	case model.TimezoneTokenDTStart:
 		return setOnceTimeProperty(&tzProp.DTStart, value, propertyName, timezoneLocation)
 	case model.TimezoneTokenComment:
-		tzProp.Comment = append(tzProp.Comment, value)
+		return p.appendTextProperty(&tzProp.Comment, value, params, propertyName)
 	case model.TimezoneTokenRdate:
-		parsedTime, err := icaldur.ParseIcalTime(value)
-		if err != nil {
-			return fmt.Errorf("%w: %s", errInvalidTimezoneProperty, err.Error())
+		// RDATE may list several onsets, eg: RDATE:19710101T000000,19720101T000000.
+		for rdate := range strings.SplitSeq(value, ",") {
+			parsedTime, err := icaldur.ParseIcalTime(rdate)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrInvalidTimezoneProperty, err.Error())
+			}
+			tzProp.Rdate = append(tzProp.Rdate, parsedTime)
 		}
-		tzProp.Rdate = append(tzProp.Rdate, parsedTime)
 	case model.TimezoneTokenTimeZoneName:
-		tzProp.TimeZoneName = append(tzProp.TimeZoneName, value)
+		tzProp.TimeZoneName = append(tzProp.TimeZoneName, p.text(value))
+	case model.TimezoneTokenRRule:
+		rule, err := rrule.ParseRRule(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidPropertyValue, propertyName, err)
+		}
+		return setOnceProperty(&tzProp.RRule, rule, propertyName, timezoneLocation)
 	default:
-		return fmt.Errorf("%w: %s", errInvalidTimezoneProperty, propertyName)
+		return p.appendExtraProperty(&tzProp.XProp, &tzProp.IANAProp, propertyName, value, params, ErrInvalidTimezoneProperty)
+	}
+	return nil
+}
+
+// validateObservance ensures that a STANDARD or DAYLIGHT sub-component has both of its UTC offsets.
+// setProperties lists the set-once properties read in it, see frame.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.6.5
+func validateObservance(setProperties []string) error {
+	if !slices.Contains(setProperties, string(model.TimezoneTokenTimeZoneOffsetFrom)) {
+		return ErrMissingTimezoneOffsetFromProperty
+	}
+	if !slices.Contains(setProperties, string(model.TimezoneTokenTimeZoneOffsetTo)) {
+		return ErrMissingTimezoneOffsetToProperty
 	}
 	return nil
 }
@@ -68,7 +81,7 @@ func parseTimeZonePropertySubComponent(propertyName string, value string, _ map[
 // validateTimeZone ensures that all required values are present for a timezone.
 func validateTimeZone(timezone *model.TimeZone) error {
 	if timezone.TimeZoneID == "" {
-		return errMissingTimezoneTZIDProperty
+		return ErrMissingTimezoneTZIDProperty
 	}
 	return nil
 }
@@ -1,121 +1,128 @@
 package parse
 
 import (
-	"fmt"
-	"net/url"
-	"strconv"
-	"strings"
-	"time"
-
 	"github.com/michael-gallo/simpleical/model"
 )
 
 const todoLocation = "Todo"
 
+// Values RFC 5545 defines for the enumerated properties of a VTODO.
+var (
+	todoStatuses = []model.TodoStatus{model.TodoStatusNeedsAction, model.TodoStatusCompleted, model.TodoStatusInProcess, model.TodoStatusCancelled}
+	todoTransps  = []model.TodoTransp{model.TodoTranspOpaque, model.TodoTranspTransparent}
+)
+
 // parseTodoProperty parses a single property line and adds it to the provided todo.
-func parseTodoProperty(propertyName string, value string, params map[string]string, todo *model.Todo) error {
+func (p *parser) parseTodoProperty(propertyName string, value string, params model.Parameters, todo *model.Todo) error {
 	switch model.TodoToken(propertyName) {
 	case model.TodoTokenDTStamp:
 		return setOnceTimeProperty(&todo.DTStamp, value, propertyName, todoLocation)
 	case model.TodoTokenUID:
-		return setOnceProperty(&todo.UID, value, propertyName, todoLocation)
+		return setOnceProperty(&todo.UID, p.text(value), propertyName, todoLocation)
 	case model.TodoTokenClass:
-		return setOnceProperty(&todo.Class, model.TodoClass(value), propertyName, todoLocation)
+		class, err := enumValue(&p.options, value, propertyName, classes, true)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&todo.Class, class, propertyName, todoLocation)
 	case model.TodoTokenCompleted:
 		return setOnceTimeProperty(&todo.Completed, value, propertyName, todoLocation)
 	case model.TodoTokenCreated:
 		return setOnceTimeProperty(&todo.Created, value, propertyName, todoLocation)
 	case model.TodoTokenDescription:
-		todo.Description = append(todo.Description, value)
-		return nil
+		return p.appendTextProperty(&todo.Description, value, params, propertyName)
 	case model.TodoTokenDTStart:
-		return setOnceTimeProperty(&todo.DTStart, value, propertyName, todoLocation)
+		return p.setOnceDateTimeProperty(&todo.DTStart, value, params, propertyName, todoLocation)
 
 	// Due and Duration are mutually exclusive
 	case model.TodoTokenDue:
 		if todo.Duration != 0 {
-			return errInvalidDurationPropertyDue
+			return ErrInvalidDurationPropertyDue
 		}
-		return setOnceTimeProperty(&todo.Due, value, propertyName, todoLocation)
+		return p.setOnceDateTimeProperty(&todo.Due, value, params, propertyName, todoLocation)
 	case model.TodoTokenDuration:
-		if todo.Due != (time.Time{}) {
-			return errInvalidDurationPropertyDue
+		if !todo.Due.IsZero() {
+			return ErrInvalidDurationPropertyDue
 		}
 		return setOnceDurationProperty(&todo.Duration, value, propertyName, todoLocation)
 
 	case model.TodoTokenGeo:
-		if todo.Geo != nil {
-			return fmt.Errorf("%w: %s", errDuplicateProperty, propertyName)
-		}
-		// Geo must be two floats separated by a semicolon
-		latitudeString, longitudeString, found := strings.Cut(value, ";")
-		if !found {
-			return errInvalidGeoProperty
-		}
-		latitude, err := strconv.ParseFloat(latitudeString, 64)
-		if err != nil {
-			return errInvalidGeoPropertyLatitude
-		}
-		longitude, err := strconv.ParseFloat(longitudeString, 64)
-		if err != nil {
-			return errInvalidGeoPropertyLongitude
-		}
-		todo.Geo = append(todo.Geo, latitude, longitude)
+		return setOnceGeoProperty(&todo.Geo, value, propertyName, todoLocation)
 	case model.TodoTokenLastModified:
 		return setOnceTimeProperty(&todo.LastModified, value, propertyName, todoLocation)
 	case model.TodoTokenLocation:
-		return setOnceProperty(&todo.Location, value, propertyName, todoLocation)
+		return p.setOnceTextProperty(&todo.Location, value, params, propertyName, todoLocation)
 	case model.TodoTokenOrganizer:
 		organizer, err := parseOrganizer(value, params)
 		if err != nil {
 			return err
 		}
-		todo.Organizer = organizer
+		return setOnceProperty(&todo.Organizer, organizer, propertyName, todoLocation)
 	case model.TodoTokenPercentComplete:
-		return setOnceIntProperty(&todo.PercentComplete, value, propertyName, todoLocation)
+		return p.setOnceIntProperty(&todo.PercentComplete, value, propertyName, todoLocation)
 	case model.TodoTokenPriority:
-		return setOnceIntProperty(&todo.Priority, value, propertyName, todoLocation)
+		return p.setOncePriorityProperty(&todo.Priority, value, propertyName, todoLocation)
 	case model.TodoTokenRecurrenceID:
-		return setOnceTimeProperty(&todo.RecurrenceID, value, propertyName, todoLocation)
+		return p.setOnceDateTimeProperty(&todo.RecurrenceID, value, params, propertyName, todoLocation)
 	case model.TodoTokenSequence:
-		return setOnceIntProperty(&todo.Sequence, value, propertyName, todoLocation)
+		return p.setOnceIntProperty(&todo.Sequence, value, propertyName, todoLocation)
 	case model.TodoTokenStatus:
-		todo.Status = model.TodoStatus(value)
+		status, err := enumValue(&p.options, value, propertyName, todoStatuses, false)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&todo.Status, status, propertyName, todoLocation)
 	case model.TodoTokenSummary:
-		return setOnceProperty(&todo.Summary, value, propertyName, todoLocation)
+		return p.setOnceTextProperty(&todo.Summary, value, params, propertyName, todoLocation)
 	case model.TodoTokenTransp:
-		return setOnceProperty(&todo.Transp, model.TodoTransp(value), propertyName, todoLocation)
+		transp, err := enumValue(&p.options, value, propertyName, todoTransps, false)
+		if err != nil {
+			return err
+		}
+		return setOnceProperty(&todo.Transp, transp, propertyName, todoLocation)
 	case model.TodoTokenURL:
 		return setOnceProperty(&todo.URL, value, propertyName, todoLocation)
 
 	// Repeatable properties
 	case model.TodoTokenAttach:
-		todo.Attach = append(todo.Attach, value)
+		attachment, err := parseAttachment(value, params)
+		if err != nil {
+			return err
+		}
+		todo.Attach = append(todo.Attach, attachment)
 		return nil
 	case model.TodoTokenAttendee:
-		parsedURL, err := url.Parse(value)
+		attendee, err := p.parseAttendee(value, params)
 		if err != nil {
 			return err
 		}
-		todo.Attendees = append(todo.Attendees, *parsedURL)
+		todo.Attendees = append(todo.Attendees, attendee)
 	case model.TodoTokenCategories:
-		todo.Categories = append(todo.Categories, strings.Split(value, ",")...)
+		todo.Categories = append(todo.Categories, p.textList(value)...)
 	case model.TodoTokenComment:
-		todo.Comment = append(todo.Comment, value)
+		return p.appendTextProperty(&todo.Comment, value, params, propertyName)
 	case model.TodoTokenContact:
-		todo.Contacts = append(todo.Contacts, value)
+		return p.appendTextProperty(&todo.Contacts, value, params, propertyName)
 	case model.TodoTokenExceptionDates:
-		return appendTimeProperty(&todo.ExceptionDates, value, propertyName, todoLocation)
+		return p.appendDateTimeProperty(&todo.ExceptionDates, value, params, propertyName, todoLocation)
 	case model.TodoTokenRequestStatus:
-		todo.RequestStatus = append(todo.RequestStatus, value)
+		requestStatus, err := p.parseRequestStatus(value, params)
+		if err != nil {
+			return err
+		}
+		todo.RequestStatus = append(todo.RequestStatus, requestStatus)
 	case model.TodoTokenRelated:
-		todo.Related = append(todo.Related, value)
+		relation, err := p.parseRelation(value, params)
+		if err != nil {
+			return err
+		}
+		todo.Related = append(todo.Related, relation)
 	case model.TodoTokenResources:
-		todo.Resources = append(todo.Resources, strings.Split(value, ",")...)
+		return p.appendTextListProperty(&todo.Resources, value, params, propertyName)
 	case model.TodoTokenRdate:
-		return appendTimeProperty(&todo.Rdate, value, propertyName, todoLocation)
+		return p.appendRdateProperty(&todo.Rdate, &todo.IANAProp, value, params, propertyName, todoLocation)
 	default:
-		return fmt.Errorf("%w: %s", errInvalidTodoProperty, propertyName)
+		return p.appendExtraProperty(&todo.XProp, &todo.IANAProp, propertyName, value, params, ErrInvalidTodoProperty)
 	}
 	return nil
 }
@@ -123,10 +130,10 @@ func parseTodoProperty(propertyName string, value string, params map[string]stri
 // validateTodo ensures that all required values are present for a todo.
 func validateTodo(todo *model.Todo) error {
 	if todo.UID == "" {
-		return errMissingTodoUIDProperty
+		return ErrMissingTodoUIDProperty
 	}
-	if time.Time.IsZero(todo.DTStart) {
-		return errMissingTodoDTStartProperty
+	if todo.DTStart.IsZero() {
+		return ErrMissingTodoDTStartProperty
 	}
 	return nil
 }
@@ -2,16 +2,19 @@ package parse
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
+
+	"github.com/michael-gallo/simpleical/model"
 )
 
 // parseIcalLineWithReusableMap parses a single property line using a reusable parameter map.
 // This avoids allocating a new map for every property with parameters.
-func parseIcalLineWithReusableMap(line string, reusableParams map[string]string) (propertyName string, params map[string]string, value string, err error) {
+func parseIcalLineWithReusableMap(line string, reusableParams model.Parameters) (propertyName string, params model.Parameters, value string, err error) {
 	// Find the first colon that is not inside quotes
 	colonIndex := findUnquotedColonIndex(line)
 	if colonIndex == -1 {
-		err = fmt.Errorf("%w: %s", errInvalidPropertyLine, line)
+		err = fmt.Errorf("%w: %s", ErrInvalidPropertyLine, line)
 		return "", nil, "", err
 	}
 
@@ -27,91 +30,157 @@ func parseIcalLineWithReusableMap(line string, reusableParams map[string]string)
 		if paramString != "" {
 			// Use the reusable map (caller has already cleared it)
 			params = reusableParams
-			splitParametersWithReusableMap(paramString, params)
+			if err = splitParametersWithReusableMap(paramString, params); err != nil {
+				return "", nil, "", err
+			}
 		}
 	}
 
 	return propertyName, params, line[colonIndex+1:], nil
 }
 
-// splitParametersWithReusableMap splits parameters using a reusable map and string builder.
-// This avoids allocating new objects for every parameter parsing operation.
-func splitParametersWithReusableMap(paramString string, params map[string]string) {
-	var current strings.Builder
-	// Pre-allocate capacity based on typical parameter length
-	current.Grow(len(paramString) / 2)
-
-	var currentKey string
-	inQuotes := false
+// splitParametersWithReusableMap splits the parameters of a content line into a reusable map.
+// Each parameter is a name followed by one or more comma separated values, which may be quoted.
+// Names are upper-cased, as they are case-insensitive, and the values of a repeated name are appended.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.1
+func splitParametersWithReusableMap(paramString string, params model.Parameters) error {
+	for paramString != "" {
+		equalsIndex := strings.IndexByte(paramString, '=')
+		if equalsIndex == -1 {
+			return fmt.Errorf("%w: parameter without a value: %s", ErrInvalidPropertyLine, paramString)
+		}
+		name := upperASCII(paramString[:equalsIndex])
+		if !isValidPropertyName(name) {
+			return fmt.Errorf("%w: invalid parameter name: %s", ErrInvalidPropertyLine, name)
+		}
+		paramString = paramString[equalsIndex+1:]
 
-	for _, character := range paramString {
-		switch character {
-		case '"':
-			inQuotes = !inQuotes
-		case '=':
-			if inQuotes {
-				current.WriteRune(character)
-				continue
-			}
-			currentKey = current.String()
-			current.Reset()
-		case ';':
-			if inQuotes {
-				current.WriteRune(character)
-				continue
+		values := params[name]
+		for {
+			var value string
+			if strings.HasPrefix(paramString, `"`) {
+				closingQuote := strings.IndexByte(paramString[1:], '"')
+				if closingQuote == -1 {
+					return fmt.Errorf("%w: unterminated quoted parameter value: %s", ErrInvalidPropertyLine, name)
+				}
+				value = paramString[1 : closingQuote+1]
+				paramString = paramString[closingQuote+2:]
+			} else {
+				end := strings.IndexAny(paramString, ",;")
+				if end == -1 {
+					end = len(paramString)
+				}
+				value = paramString[:end]
+				paramString = paramString[end:]
 			}
-			// Found a parameter separator, write the parameter.
-			if current.Len() > 0 {
-				params[currentKey] = current.String()
-				current.Reset()
+			values = append(values, decodeParameterValue(value))
+
+			// A comma continues the list of values, a semicolon starts the next parameter.
+			if !strings.HasPrefix(paramString, ",") {
+				break
 			}
+			paramString = paramString[1:]
+		}
+		params[name] = values
+
+		switch {
+		case paramString == "":
+		case paramString[0] == ';':
+			paramString = paramString[1:]
 		default:
-			current.WriteRune(character)
+			return fmt.Errorf("%w: unexpected text after parameter value: %s", ErrInvalidPropertyLine, name)
 		}
 	}
-	// Write the last parameter (it never hit a semicolon).
-	if current.Len() > 0 {
-		params[currentKey] = current.String()
-	}
+	return nil
 }
 
-// splitParameters splits a parameter string by semicolons, respecting quoted strings.
-func splitParameters(paramString string) map[string]string {
-	var params = make(map[string]string, 1)
-	var current strings.Builder
-	var currentKey string
-	inQuotes := false
+// decodeParameterValue decodes the RFC 6868 caret escapes of a parameter value:
+// `^n` is a newline, `^'` a double quote and `^^` a caret. Any other caret is kept as written.
+// Values without a caret are returned as-is without allocating.
+// https://datatracker.ietf.org/doc/html/rfc6868#section-3
+func decodeParameterValue(value string) string {
+	firstCaret := strings.IndexByte(value, '^')
+	if firstCaret == -1 {
+		return value
+	}
 
-	for _, character := range paramString {
-		switch character {
-		case '"':
-			inQuotes = !inQuotes
-		case '=':
-			if inQuotes {
-				current.WriteRune(character)
-				continue
-			}
-			currentKey = current.String()
-			current.Reset()
-		case ';':
-			if inQuotes {
-				current.WriteRune(character)
-				continue
-			}
-			// Found a parameter separator, write the parameter.
-			if current.Len() > 0 {
-				params[currentKey] = current.String()
-				current.Reset()
-			}
+	var builder strings.Builder
+	builder.Grow(len(value))
+	builder.WriteString(value[:firstCaret])
+	for i := firstCaret; i < len(value); i++ {
+		character := value[i]
+		if character != '^' || i == len(value)-1 {
+			builder.WriteByte(character)
+			continue
+		}
+		switch value[i+1] {
+		case 'n':
+			builder.WriteByte('\n')
+		case '\'':
+			builder.WriteByte('"')
+		case '^':
+			builder.WriteByte('^')
 		default:
-			current.WriteRune(character)
+			builder.WriteByte('^')
+			continue
 		}
+		i++
 	}
-	// Write the last parameter (it never hit a semicolon).
-	if current.Len() > 0 {
-		params[currentKey] = current.String()
+	return builder.String()
+}
+
+// upperASCII returns name with its ASCII letters upper-cased, as names and enumerated values are case-insensitive.
+// Other bytes are left alone so the length never changes.
+// Names that are already upper-case, the common case, are returned without allocating.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-2
+func upperASCII(name string) string {
+	firstLower := -1
+	for i := 0; i < len(name); i++ {
+		if name[i] >= 'a' && name[i] <= 'z' {
+			firstLower = i
+			break
+		}
 	}
-	return params
+	if firstLower == -1 {
+		return name
+	}
+
+	upper := []byte(name)
+	for i := firstLower; i < len(upper); i++ {
+		if upper[i] >= 'a' && upper[i] <= 'z' {
+			upper[i] -= 'a' - 'A'
+		}
+	}
+	return string(upper)
+}
+
+// parseURI parses a URI value, such as a CAL-ADDRESS or the value of a DIR parameter.
+// name is the property or parameter the value belongs to, for the error.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.13
+func parseURI(value, name string) (*url.URL, error) {
+	uri, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidPropertyValue, name, err)
+	}
+	return uri, nil
+}
+
+// isValidPropertyName reports whether name is a valid iana-token or x-name,
+// ie: one or more letters, digits and dashes.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.1
+func isValidPropertyName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		character := name[i]
+		isLetter := (character >= 'A' && character <= 'Z') || (character >= 'a' && character <= 'z')
+		isDigit := character >= '0' && character <= '9'
+		if !isLetter && !isDigit && character != '-' {
+			return false
+		}
+	}
+	return true
 }
 
 // findUnquotedColonIndex finds the first colon that is not encapsulated in quotations.
@@ -126,3 +195,32 @@ func findUnquotedColonIndex(line string) int {
 	}
 	return -1
 }
+
+// splitTextList splits a comma separated list of TEXT values, as used by CATEGORIES and RESOURCES.
+// Escaped commas (`\,`) do not separate values. When decode is true each value is also unescaped.
+func splitTextList(value string, decode bool) []string {
+	if strings.IndexByte(value, '\\') == -1 {
+		return strings.Split(value, ",")
+	}
+
+	values := make([]string, 0, strings.Count(value, ",")+1)
+	start := 0
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			// Skip the escaped character, it can never be a separator.
+			i++
+		case ',':
+			values = append(values, value[start:i])
+			start = i + 1
+		}
+	}
+	values = append(values, value[start:])
+
+	if decode {
+		for i := range values {
+			values[i] = model.UnescapeText(values[i])
+		}
+	}
+	return values
+}
@@ -1,4 +1,4 @@
 // Package rrule parses iCalendar recurrence rules (RFC 5545 section 3.3.10).
 //
-// Use ParseRRule to parse RRULE strings into structured values.
+// Use ParseRRule to parse RRULE strings into structured values, and RRule.String to write them back.
 package rrule
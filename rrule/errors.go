@@ -24,4 +24,7 @@ var (
 	errInvalidByDayString = errors.New("invalid BYDAY string")
 
 	errInvalidFrequency = errors.New("invalid frequency")
+
+	// errInvalidWeekStart is returned when the WKST value is not a weekday.
+	errInvalidWeekStart = errors.New("invalid WKST")
 )
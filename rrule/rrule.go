@@ -5,7 +5,10 @@
 package rrule
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -46,8 +49,26 @@ type ByDay struct {
 	// The interval between occurrences of the event.
 	// eg: If Weekday is Tuesday, and Interval is 2, then the event will happen every other Tuesday.
 	Interval int
+	// HasOrdinal reports whether an Interval of 1 was written before the weekday, eg: 1MO rather than MO.
+	// Both are read with an Interval of 1, but in a MONTHLY rule 1MO is the first Monday of the month and MO every Monday.
+	// It is only set for an Interval of 0 or 1, as any other Interval is always written before the weekday.
+	HasOrdinal bool
 }
 
+// UntilKind tells which form the UNTIL of a rule was written in.
+// RFC 5545 requires it to match the form of the DTSTART of the component the rule belongs to.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.10.
+type UntilKind int
+
+const (
+	// UntilUTC is a DATE-TIME in UTC, eg: 19971224T000000Z.
+	UntilUTC UntilKind = iota
+	// UntilFloating is a DATE-TIME without a time zone, eg: 19971224T000000, as used in VTIMEZONE components.
+	UntilFloating
+	// UntilDate is a DATE, eg: 19971224, as used by the rules of all-day events.
+	UntilDate
+)
+
 // RRule represents an ical reccurence rule.
 // https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.10.
 type RRule struct {
@@ -64,7 +85,10 @@ type RRule struct {
 	Count *int
 	// The date and time until the rule ends, inclusive.
 	// Can not occur with the Count property.
+	// A floating time or a DATE holds its wall clock time, or midnight of the date, in time.UTC.
 	Until *time.Time
+	// The form Until was written in.
+	UntilKind UntilKind
 	// The day of the week that the event occurs on.
 	// This is optional and repeatable.
 	Weekday []ByDay
@@ -80,9 +104,34 @@ type RRule struct {
 	// The day of the year that the event occurs on.
 	// eg: 100th day of the year, negative numbers are allowed to indicate the last day of the year.
 	YearDay []int
+
+	// The second(s) of the minute that the event occurs on.
+	Second []int
+
+	// The minute(s) of the hour that the event occurs on.
+	Minute []int
+
+	// The hour(s) of the day that the event occurs on.
+	Hour []int
+
+	// The week(s) of the year that the event occurs on, counted as ISO 8601 weeks starting on WeekStart.
+	// Negative numbers count from the last week of the year.
+	WeekNo []int
+
+	// The occurrences within each interval of the frequency that the rule is limited to.
+	// eg: FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1 is the last weekday of the month.
+	SetPos []int
+
+	// The day the week starts on. It is Monday when empty.
+	WeekStart Weekday
+
+	// Rule parts that are not defined by RFC 5545, such as the RSCALE and SKIP of RFC 7529, by name.
+	// Their values are kept as they were read.
+	OtherParts map[string]string
 }
 
 // ParseRRule takes an iCal reccurence rule string and parses it into a RRule struct.
+// Rule part names and the FREQ, BYDAY and WKST values are case-insensitive.
 // https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.10.
 // https://datatracker.ietf.org/doc/html/rfc5545#section-3.8.5.3.
 func ParseRRule(rruleString string) (*RRule, error) {
@@ -95,12 +144,13 @@ func ParseRRule(rruleString string) (*RRule, error) {
 		if !found {
 			return nil, errInvalidRRuleString
 		}
+		tag = upperASCII(tag)
 		switch tag {
 		case "FREQ":
 			// Validate the frequency is valid
-			switch Frequency(value) {
+			switch frequency := Frequency(upperASCII(value)); frequency {
 			case FrequencySecondly, FrequencyMinutely, FrequencyHourly, FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
-				rrule.Frequency = Frequency(value)
+				rrule.Frequency = frequency
 			default:
 				return nil, fmt.Errorf("%w: %s", errInvalidFrequency, value)
 			}
@@ -117,52 +167,83 @@ func ParseRRule(rruleString string) (*RRule, error) {
 			}
 			rrule.Count = &count
 		case "UNTIL":
-			until, err := icaldur.ParseIcalTime(value)
+			until, kind, err := parseUntil(value)
 			if err != nil {
 				return nil, err
 			}
 			rrule.Until = &until
+			rrule.UntilKind = kind
 		case "BYDAY":
-			weekdays := strings.Split(value, ",")
+			weekdays := strings.Split(upperASCII(value), ",")
 			rrule.Weekday = make([]ByDay, 0, len(weekdays))
-			for _, weekday := range weekdays {
+			for _, byDay := range weekdays {
 				// if there is an interval other than 1, it can be expressed as the number at the start of the string
-				interval, weekday, err := parseByDay(weekday)
+				interval, weekday, err := parseByDay(byDay)
 				if err != nil {
 					return nil, err
 				}
-				rrule.Weekday = append(rrule.Weekday, ByDay{Weekday: weekday, Interval: interval})
+				hasOrdinal := len(byDay) > len(weekday) && (interval == 0 || interval == 1)
+				rrule.Weekday = append(rrule.Weekday, ByDay{Weekday: weekday, Interval: interval, HasOrdinal: hasOrdinal})
 			}
 		case "BYMONTH":
-			months := strings.Split(value, ",")
-			rrule.Month = make([]int, 0, len(months))
-			for _, month := range months {
-				monthInt, err := strconv.Atoi(month)
-				if err != nil {
-					return nil, err
-				}
-				rrule.Month = append(rrule.Month, monthInt)
+			months, err := parseIntList(value)
+			if err != nil {
+				return nil, err
 			}
+			rrule.Month = months
 		case "BYMONTHDAY":
-			monthdays := strings.Split(value, ",")
-			rrule.Monthday = make([]int, 0, len(monthdays))
-			for _, monthday := range monthdays {
-				monthdayInt, err := strconv.Atoi(monthday)
-				if err != nil {
-					return nil, err
-				}
-				rrule.Monthday = append(rrule.Monthday, monthdayInt)
+			monthdays, err := parseIntList(value)
+			if err != nil {
+				return nil, err
 			}
+			rrule.Monthday = monthdays
 		case "BYYEARDAY":
-			yeardays := strings.Split(value, ",")
-			rrule.YearDay = make([]int, 0, len(yeardays))
-			for _, yearday := range yeardays {
-				yeardayInt, err := strconv.Atoi(yearday)
-				if err != nil {
-					return nil, err
-				}
-				rrule.YearDay = append(rrule.YearDay, yeardayInt)
+			yeardays, err := parseIntList(value)
+			if err != nil {
+				return nil, err
+			}
+			rrule.YearDay = yeardays
+		case "BYSECOND":
+			seconds, err := parseIntList(value)
+			if err != nil {
+				return nil, err
+			}
+			rrule.Second = seconds
+		case "BYMINUTE":
+			minutes, err := parseIntList(value)
+			if err != nil {
+				return nil, err
+			}
+			rrule.Minute = minutes
+		case "BYHOUR":
+			hours, err := parseIntList(value)
+			if err != nil {
+				return nil, err
+			}
+			rrule.Hour = hours
+		case "BYWEEKNO":
+			weeks, err := parseIntList(value)
+			if err != nil {
+				return nil, err
 			}
+			rrule.WeekNo = weeks
+		case "BYSETPOS":
+			positions, err := parseIntList(value)
+			if err != nil {
+				return nil, err
+			}
+			rrule.SetPos = positions
+		case "WKST":
+			weekStart := Weekday(upperASCII(value))
+			if !isValidWeekday(weekStart) {
+				return nil, fmt.Errorf("%w: %s", errInvalidWeekStart, value)
+			}
+			rrule.WeekStart = weekStart
+		default:
+			if rrule.OtherParts == nil {
+				rrule.OtherParts = make(map[string]string, 1)
+			}
+			rrule.OtherParts[tag] = value
 		}
 	}
 	if err := validateRRule(rrule); err != nil {
@@ -171,6 +252,200 @@ func ParseRRule(rruleString string) (*RRule, error) {
 	return rrule, nil
 }
 
+// parseUntil parses an UNTIL value, which is a DATE or a DATE-TIME, and reports which form it was written in.
+func parseUntil(value string) (time.Time, UntilKind, error) {
+	switch {
+	case len(value) == 8:
+		until, err := icaldur.ParseIcalDate(value)
+		return until, UntilDate, err
+	case strings.HasSuffix(value, "Z"):
+		until, err := icaldur.ParseIcalTime(value)
+		return until, UntilUTC, err
+	default:
+		until, err := icaldur.ParseIcalTime(value)
+		return until, UntilFloating, err
+	}
+}
+
+// String returns the rule in the form of an RRULE value, eg: FREQ=WEEKLY;COUNT=4;BYDAY=MO,WE, the inverse of ParseRRule.
+// The rule parts are written in the order RFC 5545 lists them, followed by OtherParts sorted by name.
+// An interval of 1, the default, is left out, as is the ordinal of a BYDAY value with an interval of 0 or 1,
+// unless HasOrdinal is set.
+// UNTIL is written in the form given by UntilKind.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.10.
+func (r *RRule) String() string {
+	var builder strings.Builder
+	builder.WriteString("FREQ=")
+	builder.WriteString(string(r.Frequency))
+	if r.Until != nil {
+		builder.WriteString(";UNTIL=")
+		switch r.UntilKind {
+		case UntilDate:
+			builder.WriteString(r.Until.Format("20060102"))
+		case UntilFloating:
+			builder.WriteString(r.Until.Format("20060102T150405"))
+		default:
+			builder.WriteString(r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if r.Count != nil {
+		builder.WriteString(";COUNT=")
+		builder.WriteString(strconv.Itoa(*r.Count))
+	}
+	if r.Interval > 1 {
+		builder.WriteString(";INTERVAL=")
+		builder.WriteString(strconv.Itoa(r.Interval))
+	}
+	writeIntList(&builder, "BYSECOND", r.Second)
+	writeIntList(&builder, "BYMINUTE", r.Minute)
+	writeIntList(&builder, "BYHOUR", r.Hour)
+	if len(r.Weekday) > 0 {
+		builder.WriteString(";BYDAY=")
+		for i, byDay := range r.Weekday {
+			if i > 0 {
+				builder.WriteByte(',')
+			}
+			if ordinal, ok := byDay.ordinal(); ok {
+				builder.WriteString(strconv.Itoa(ordinal))
+			}
+			builder.WriteString(string(byDay.Weekday))
+		}
+	}
+	writeIntList(&builder, "BYMONTHDAY", r.Monthday)
+	writeIntList(&builder, "BYYEARDAY", r.YearDay)
+	writeIntList(&builder, "BYWEEKNO", r.WeekNo)
+	writeIntList(&builder, "BYMONTH", r.Month)
+	writeIntList(&builder, "BYSETPOS", r.SetPos)
+	if r.WeekStart != "" {
+		builder.WriteString(";WKST=")
+		builder.WriteString(string(r.WeekStart))
+	}
+	for _, name := range slices.Sorted(maps.Keys(r.OtherParts)) {
+		builder.WriteByte(';')
+		builder.WriteString(name)
+		builder.WriteByte('=')
+		builder.WriteString(r.OtherParts[name])
+	}
+	return builder.String()
+}
+
+// ordinal returns the number written before the weekday of a BYDAY value, and reports false if there is none.
+func (b ByDay) ordinal() (int, bool) {
+	return b.Interval, b.HasOrdinal || (b.Interval != 0 && b.Interval != 1)
+}
+
+// parseIntList parses a rule part holding a comma separated list of numbers, eg: BYMONTH=1,6.
+func parseIntList(value string) ([]int, error) {
+	values := strings.Split(value, ",")
+	numbers := make([]int, 0, len(values))
+	for _, value := range values {
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// writeIntList writes a rule part holding a list of numbers, eg: ;BYMONTH=1,6, unless the list is empty.
+func writeIntList(builder *strings.Builder, name string, values []int) {
+	if len(values) == 0 {
+		return
+	}
+	builder.WriteByte(';')
+	builder.WriteString(name)
+	builder.WriteByte('=')
+	for i, value := range values {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(strconv.Itoa(value))
+	}
+}
+
+// Clone returns a deep copy of the rule, or nil if the rule is nil.
+func (r *RRule) Clone() *RRule {
+	if r == nil {
+		return nil
+	}
+	clone := *r
+	if r.Count != nil {
+		count := *r.Count
+		clone.Count = &count
+	}
+	if r.Until != nil {
+		until := *r.Until
+		clone.Until = &until
+	}
+	clone.Weekday = slices.Clone(r.Weekday)
+	clone.Month = slices.Clone(r.Month)
+	clone.Monthday = slices.Clone(r.Monthday)
+	clone.YearDay = slices.Clone(r.YearDay)
+	clone.Second = slices.Clone(r.Second)
+	clone.Minute = slices.Clone(r.Minute)
+	clone.Hour = slices.Clone(r.Hour)
+	clone.WeekNo = slices.Clone(r.WeekNo)
+	clone.SetPos = slices.Clone(r.SetPos)
+	clone.OtherParts = maps.Clone(r.OtherParts)
+	return &clone
+}
+
+// Equal reports whether two rules describe the same recurrence.
+// A missing interval is the same as an interval of 1, a missing WKST is the same as MO, UNTIL is compared
+// as an instant in the same form, and the order of the BYxxx values does not matter.
+func (r *RRule) Equal(other *RRule) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	if r.Frequency != other.Frequency || max(r.Interval, 1) != max(other.Interval, 1) {
+		return false
+	}
+	if (r.Count == nil) != (other.Count == nil) || (r.Count != nil && *r.Count != *other.Count) {
+		return false
+	}
+	if (r.Until == nil) != (other.Until == nil) || (r.Until != nil && (r.UntilKind != other.UntilKind || !r.Until.Equal(*other.Until))) {
+		return false
+	}
+	return equalSorted(r.Weekday, other.Weekday, compareByDay) &&
+		equalSorted(r.Month, other.Month, cmp.Compare[int]) &&
+		equalSorted(r.Monthday, other.Monthday, cmp.Compare[int]) &&
+		equalSorted(r.YearDay, other.YearDay, cmp.Compare[int]) &&
+		equalSorted(r.Second, other.Second, cmp.Compare[int]) &&
+		equalSorted(r.Minute, other.Minute, cmp.Compare[int]) &&
+		equalSorted(r.Hour, other.Hour, cmp.Compare[int]) &&
+		equalSorted(r.WeekNo, other.WeekNo, cmp.Compare[int]) &&
+		equalSorted(r.SetPos, other.SetPos, cmp.Compare[int]) &&
+		cmp.Or(r.WeekStart, WeekdayMonday) == cmp.Or(other.WeekStart, WeekdayMonday) &&
+		maps.Equal(r.OtherParts, other.OtherParts)
+}
+
+// equalSorted reports whether a and b hold the same values in any order.
+// compare must return 0 for exactly the values that are the same.
+func equalSorted[T any](a, b []T, compare func(T, T) int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.SortFunc(a, compare)
+	slices.SortFunc(b, compare)
+	return slices.EqualFunc(a, b, func(x, y T) bool { return compare(x, y) == 0 })
+}
+
+// compareByDay orders BYDAY values by weekday, then by the ordinal written before it, if any.
+// MO and a MO with an Interval of 0 or 1 but no ordinal are the same value, while 1MO is not.
+func compareByDay(a, b ByDay) int {
+	aInterval, aOrdinal := a.ordinal()
+	bInterval, bOrdinal := b.ordinal()
+	if !aOrdinal {
+		aInterval = 0
+	}
+	if !bOrdinal {
+		bInterval = 0
+	}
+	return cmp.Or(cmp.Compare(a.Weekday, b.Weekday), cmp.Compare(aInterval, bInterval))
+}
+
 func validateRRule(rrule *RRule) error {
 	if rrule.Frequency == "" {
 		return errFrequencyRequired
@@ -254,3 +529,27 @@ func isValidFrequency(frequency Frequency) bool {
 		return false
 	}
 }
+
+// upperASCII returns value with its ASCII letters upper-cased, as rule part names and values are case-insensitive.
+// Values that are already upper-case, the common case, are returned without allocating.
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.10
+func upperASCII(value string) string {
+	firstLower := -1
+	for i := 0; i < len(value); i++ {
+		if value[i] >= 'a' && value[i] <= 'z' {
+			firstLower = i
+			break
+		}
+	}
+	if firstLower == -1 {
+		return value
+	}
+
+	upper := []byte(value)
+	for i := firstLower; i < len(upper); i++ {
+		if upper[i] >= 'a' && upper[i] <= 'z' {
+			upper[i] -= 'a' - 'A'
+		}
+	}
+	return string(upper)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/michael-gallo/simpleical/icaldur"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,6 +33,15 @@ func TestParseRRule(t *testing.T) {
 			},
 			expectError: nil,
 		},
+		{
+			name:  "Lower case rule part names and values",
+			input: "freq=weekly;interval=2;byday=mo,Fr",
+			want: &RRule{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Weekday:   []ByDay{{Weekday: WeekdayMonday, Interval: 1}, {Weekday: WeekdayFriday, Interval: 1}},
+			},
+		},
 		{
 			name:        "Invalid frequency",
 			input:       "FREQ=DALLY;INTERVAL=2;COUNT=10",
@@ -149,6 +159,55 @@ func TestParseRRule(t *testing.T) {
 			},
 			expectError: nil,
 		},
+		{
+			name:  "Yearly until a DATE, for an all-day event",
+			input: "FREQ=YEARLY;UNTIL=20300101",
+			want: &RRule{
+				Frequency: FrequencyYearly,
+				Interval:  1,
+				Until:     getPointer(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+				UntilKind: UntilDate,
+			},
+			expectError: nil,
+		},
+		{
+			name:  "Daily until a floating time",
+			input: "FREQ=DAILY;UNTIL=19971224T090000",
+			want: &RRule{
+				Frequency: FrequencyDaily,
+				Interval:  1,
+				Until:     getPointer(time.Date(1997, 12, 24, 9, 0, 0, 0, time.UTC)),
+				UntilKind: UntilFloating,
+			},
+			expectError: nil,
+		},
+		{
+			name:  "Lower case WKST",
+			input: "FREQ=WEEKLY;wkst=su",
+			want:  &RRule{Frequency: FrequencyWeekly, Interval: 1, WeekStart: WeekdaySunday},
+		},
+		{
+			name:        "Invalid rule: WKST is not a weekday",
+			input:       "FREQ=WEEKLY;WKST=XX",
+			want:        nil,
+			expectError: fmt.Errorf("%w: %s", errInvalidWeekStart, "XX"),
+		},
+		{
+			name:  "Rule parts from other specifications are kept",
+			input: "FREQ=MONTHLY;RSCALE=HEBREW;SKIP=FORWARD",
+			want: &RRule{
+				Frequency:  FrequencyMonthly,
+				Interval:   1,
+				OtherParts: map[string]string{"RSCALE": "HEBREW", "SKIP": "FORWARD"},
+			},
+			expectError: nil,
+		},
+		{
+			name:        "Invalid rule: malformed UNTIL",
+			input:       "FREQ=DAILY;UNTIL=2030011",
+			want:        nil,
+			expectError: icaldur.ErrInvalidTimeFormat,
+		},
 		{
 			name:  "Every other day - forever",
 			input: "FREQ=DAILY;INTERVAL=2",
@@ -249,7 +308,7 @@ func TestParseRRule(t *testing.T) {
 				Frequency: FrequencyMonthly,
 				Interval:  1,
 				Count:     getPointer(10),
-				Weekday:   []ByDay{{Weekday: WeekdayFriday, Interval: 1}},
+				Weekday:   []ByDay{{Weekday: WeekdayFriday, Interval: 1, HasOrdinal: true}},
 			},
 			expectError: nil,
 		},
@@ -260,7 +319,7 @@ func TestParseRRule(t *testing.T) {
 				Frequency: FrequencyMonthly,
 				Interval:  1,
 				Until:     getPointer(time.Date(1997, 12, 24, 0, 0, 0, 0, time.UTC)),
-				Weekday:   []ByDay{{Weekday: WeekdayFriday, Interval: 1}},
+				Weekday:   []ByDay{{Weekday: WeekdayFriday, Interval: 1, HasOrdinal: true}},
 			},
 			expectError: nil,
 		},
@@ -272,7 +331,7 @@ func TestParseRRule(t *testing.T) {
 				Interval:  2,
 				Count:     getPointer(10),
 				Weekday: []ByDay{
-					{Weekday: WeekdaySunday, Interval: 1},
+					{Weekday: WeekdaySunday, Interval: 1, HasOrdinal: true},
 					{Weekday: WeekdaySunday, Interval: -1},
 				},
 			},
@@ -398,112 +457,107 @@ func TestParseRRule(t *testing.T) {
 			},
 			expectError: nil,
 		},
-		// Missing RFC 5545 examples that need to be implemented
-		// TODO: Uncomment when WKST property is implemented
-		// {
-		// 	name:  "Every other week - forever with Sunday as week start",
-		// 	input: "FREQ=WEEKLY;INTERVAL=2;WKST=SU",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyWeekly,
-		// 		Interval:  2,
-		// 		WeekStart: WeekdaySunday,
-		// 	},
-		// 	expectError: nil,
-		// },
-		// {
-		// 	name:  "Weekly on Tuesday and Thursday for five weeks with Sunday as week start",
-		// 	input: "FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyWeekly,
-		// 		Interval:  1,
-		// 		Until:     getPointer(time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)),
-		// 		WeekStart: WeekdaySunday,
-		// 		Weekday: []ByDay{
-		// 			{Weekday: WeekdayTuesday, Interval: 1},
-		// 			{Weekday: WeekdayThursday, Interval: 1},
-		// 		},
-		// 	},
-		// 	expectError: nil,
-		// },
-		// {
-		// 	name:  "Every other week on Monday, Wednesday, and Friday until December 24, 1997 with Sunday as week start",
-		// 	input: "FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyWeekly,
-		// 		Interval:  2,
-		// 		Until:     getPointer(time.Date(1997, 12, 24, 0, 0, 0, 0, time.UTC)),
-		// 		WeekStart: WeekdaySunday,
-		// 		Weekday: []ByDay{
-		// 			{Weekday: WeekdayMonday, Interval: 1},
-		// 			{Weekday: WeekdayWednesday, Interval: 1},
-		// 			{Weekday: WeekdayFriday, Interval: 1},
-		// 		},
-		// 	},
-		// 	expectError: nil,
-		// },
-		// {
-		// 	name:  "Every other week on Tuesday and Thursday, for 8 occurrences with Sunday as week start",
-		// 	input: "FREQ=WEEKLY;INTERVAL=2;COUNT=8;WKST=SU;BYDAY=TU,TH",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyWeekly,
-		// 		Interval:  2,
-		// 		Count:     getPointer(8),
-		// 		WeekStart: WeekdaySunday,
-		// 		Weekday: []ByDay{
-		// 			{Weekday: WeekdayTuesday, Interval: 1},
-		// 			{Weekday: WeekdayThursday, Interval: 1},
-		// 		},
-		// 	},
-		// 	expectError: nil,
-		// },
-
-		// TODO: Uncomment when BYWEEKNO property is implemented
-		// {
-		// 	name:  "Monday of week number 20 (where the default start of the week is Monday), forever",
-		// 	input: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyYearly,
-		// 		Interval:  1,
-		// 		WeekNo:    []int{20},
-		// 		Weekday:   []ByDay{{Weekday: WeekdayMonday, Interval: 1}},
-		// 	},
-		// 	expectError: nil,
-		// },
-
-		// TODO: Uncomment when BYSETPOS property is implemented
-		// {
-		// 	name:  "The third instance into the month of one of Tuesday, Wednesday, or Thursday, for the next 3 months",
-		// 	input: "FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyMonthly,
-		// 		Interval:  1,
-		// 		Count:     getPointer(3),
-		// 		Weekday: []ByDay{
-		// 			{Weekday: WeekdayTuesday, Interval: 1},
-		// 			{Weekday: WeekdayWednesday, Interval: 1},
-		// 			{Weekday: WeekdayThursday, Interval: 1},
-		// 		},
-		// 		SetPos: []int{3},
-		// 	},
-		// 	expectError: nil,
-		// },
-		// {
-		// 	name:  "The second-to-last weekday of the month",
-		// 	input: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyMonthly,
-		// 		Interval:  1,
-		// 		Weekday: []ByDay{
-		// 			{Weekday: WeekdayMonday, Interval: 1},
-		// 			{Weekday: WeekdayTuesday, Interval: 1},
-		// 			{Weekday: WeekdayWednesday, Interval: 1},
-		// 			{Weekday: WeekdayThursday, Interval: 1},
-		// 			{Weekday: WeekdayFriday, Interval: 1},
-		// 		},
-		// 		SetPos: []int{-2},
-		// 	},
-		// 	expectError: nil,
-		// },
+		// WKST, BYWEEKNO, BYSETPOS, BYHOUR and BYMINUTE examples from RFC 5545
+		{
+			name:  "Every other week - forever with Sunday as week start",
+			input: "FREQ=WEEKLY;INTERVAL=2;WKST=SU",
+			want: &RRule{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				WeekStart: WeekdaySunday,
+			},
+			expectError: nil,
+		},
+		{
+			name:  "Weekly on Tuesday and Thursday for five weeks with Sunday as week start",
+			input: "FREQ=WEEKLY;UNTIL=19971007T000000Z;WKST=SU;BYDAY=TU,TH",
+			want: &RRule{
+				Frequency: FrequencyWeekly,
+				Interval:  1,
+				Until:     getPointer(time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)),
+				WeekStart: WeekdaySunday,
+				Weekday: []ByDay{
+					{Weekday: WeekdayTuesday, Interval: 1},
+					{Weekday: WeekdayThursday, Interval: 1},
+				},
+			},
+			expectError: nil,
+		},
+		{
+			name:  "Every other week on Monday, Wednesday, and Friday until December 24, 1997 with Sunday as week start",
+			input: "FREQ=WEEKLY;INTERVAL=2;UNTIL=19971224T000000Z;WKST=SU;BYDAY=MO,WE,FR",
+			want: &RRule{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Until:     getPointer(time.Date(1997, 12, 24, 0, 0, 0, 0, time.UTC)),
+				WeekStart: WeekdaySunday,
+				Weekday: []ByDay{
+					{Weekday: WeekdayMonday, Interval: 1},
+					{Weekday: WeekdayWednesday, Interval: 1},
+					{Weekday: WeekdayFriday, Interval: 1},
+				},
+			},
+			expectError: nil,
+		},
+		{
+			name:  "Every other week on Tuesday and Thursday, for 8 occurrences with Sunday as week start",
+			input: "FREQ=WEEKLY;INTERVAL=2;COUNT=8;WKST=SU;BYDAY=TU,TH",
+			want: &RRule{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Count:     getPointer(8),
+				WeekStart: WeekdaySunday,
+				Weekday: []ByDay{
+					{Weekday: WeekdayTuesday, Interval: 1},
+					{Weekday: WeekdayThursday, Interval: 1},
+				},
+			},
+			expectError: nil,
+		},
+		{
+			name:  "Monday of week number 20 (where the default start of the week is Monday), forever",
+			input: "FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO",
+			want: &RRule{
+				Frequency: FrequencyYearly,
+				Interval:  1,
+				WeekNo:    []int{20},
+				Weekday:   []ByDay{{Weekday: WeekdayMonday, Interval: 1}},
+			},
+			expectError: nil,
+		},
+		{
+			name:  "The third instance into the month of one of Tuesday, Wednesday, or Thursday, for the next 3 months",
+			input: "FREQ=MONTHLY;COUNT=3;BYDAY=TU,WE,TH;BYSETPOS=3",
+			want: &RRule{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				Count:     getPointer(3),
+				Weekday: []ByDay{
+					{Weekday: WeekdayTuesday, Interval: 1},
+					{Weekday: WeekdayWednesday, Interval: 1},
+					{Weekday: WeekdayThursday, Interval: 1},
+				},
+				SetPos: []int{3},
+			},
+			expectError: nil,
+		},
+		{
+			name:  "The second-to-last weekday of the month",
+			input: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-2",
+			want: &RRule{
+				Frequency: FrequencyMonthly,
+				Interval:  1,
+				Weekday: []ByDay{
+					{Weekday: WeekdayMonday, Interval: 1},
+					{Weekday: WeekdayTuesday, Interval: 1},
+					{Weekday: WeekdayWednesday, Interval: 1},
+					{Weekday: WeekdayThursday, Interval: 1},
+					{Weekday: WeekdayFriday, Interval: 1},
+				},
+				SetPos: []int{-2},
+			},
+			expectError: nil,
+		},
 
 		// TODO: Uncomment when complex combinations with multiple BY* properties are implemented
 		// {
@@ -530,60 +584,57 @@ func TestParseRRule(t *testing.T) {
 		// 	expectError: nil,
 		// },
 
-		// TODO: Uncomment when BYHOUR and BYMINUTE properties are implemented
-		// {
-		// 	name:  "Every 20 minutes from 9:00 AM to 4:40 PM every day",
-		// 	input: "FREQ=DAILY;BYHOUR=9,10,11,12,13,14,15,16;BYMINUTE=0,20,40",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyDaily,
-		// 		Interval:  1,
-		// 		Hour:      []int{9, 10, 11, 12, 13, 14, 15, 16},
-		// 		Minute:    []int{0, 20, 40},
-		// 	},
-		// 	expectError: nil,
-		// },
-		// {
-		// 	name:  "Every 20 minutes from 9:00 AM to 4:40 PM every day (alternative with MINUTELY)",
-		// 	input: "FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10,11,12,13,14,15,16",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyMinutely,
-		// 		Interval:  20,
-		// 		Hour:      []int{9, 10, 11, 12, 13, 14, 15, 16},
-		// 	},
-		// 	expectError: nil,
-		// },
-
-		// TODO: Uncomment when WKST property is implemented
-		// {
-		// 	name:  "An example where the days generated makes a difference because of WKST (Monday start)",
-		// 	input: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyWeekly,
-		// 		Interval:  2,
-		// 		Count:     getPointer(4),
-		// 		WeekStart: WeekdayMonday,
-		// 		Weekday: []ByDay{
-		// 			{Weekday: WeekdayTuesday, Interval: 1},
-		// 			{Weekday: WeekdaySunday, Interval: 1},
-		// 		},
-		// 	},
-		// 	expectError: nil,
-		// },
-		// {
-		// 	name:  "An example where the days generated makes a difference because of WKST (Sunday start)",
-		// 	input: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
-		// 	want: &RRule{
-		// 		Frequency: FrequencyWeekly,
-		// 		Interval:  2,
-		// 		Count:     getPointer(4),
-		// 		WeekStart: WeekdaySunday,
-		// 		Weekday: []ByDay{
-		// 			{Weekday: WeekdayTuesday, Interval: 1},
-		// 			{Weekday: WeekdaySunday, Interval: 1},
-		// 		},
-		// 	},
-		// 	expectError: nil,
-		// },
+		{
+			name:  "Every 20 minutes from 9:00 AM to 4:40 PM every day",
+			input: "FREQ=DAILY;BYHOUR=9,10,11,12,13,14,15,16;BYMINUTE=0,20,40",
+			want: &RRule{
+				Frequency: FrequencyDaily,
+				Interval:  1,
+				Hour:      []int{9, 10, 11, 12, 13, 14, 15, 16},
+				Minute:    []int{0, 20, 40},
+			},
+			expectError: nil,
+		},
+		{
+			name:  "Every 20 minutes from 9:00 AM to 4:40 PM every day (alternative with MINUTELY)",
+			input: "FREQ=MINUTELY;INTERVAL=20;BYHOUR=9,10,11,12,13,14,15,16",
+			want: &RRule{
+				Frequency: FrequencyMinutely,
+				Interval:  20,
+				Hour:      []int{9, 10, 11, 12, 13, 14, 15, 16},
+			},
+			expectError: nil,
+		},
+		{
+			name:  "An example where the days generated makes a difference because of WKST (Monday start)",
+			input: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			want: &RRule{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Count:     getPointer(4),
+				WeekStart: WeekdayMonday,
+				Weekday: []ByDay{
+					{Weekday: WeekdayTuesday, Interval: 1},
+					{Weekday: WeekdaySunday, Interval: 1},
+				},
+			},
+			expectError: nil,
+		},
+		{
+			name:  "An example where the days generated makes a difference because of WKST (Sunday start)",
+			input: "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			want: &RRule{
+				Frequency: FrequencyWeekly,
+				Interval:  2,
+				Count:     getPointer(4),
+				WeekStart: WeekdaySunday,
+				Weekday: []ByDay{
+					{Weekday: WeekdayTuesday, Interval: 1},
+					{Weekday: WeekdaySunday, Interval: 1},
+				},
+			},
+			expectError: nil,
+		},
 
 		// TODO: Uncomment when complex validation is implemented
 		// {
@@ -774,3 +825,124 @@ func TestParseByDay(t *testing.T) {
 		})
 	}
 }
+
+func TestRRuleClone(t *testing.T) {
+	rule, err := ParseRRule("FREQ=MONTHLY;COUNT=10;BYDAY=1FR,-1MO;BYMONTH=1,6;BYMONTHDAY=2;BYYEARDAY=100;BYSETPOS=-1;BYHOUR=9;X-NAME=A")
+	assert.NoError(t, err)
+	until := time.Date(2025, time.December, 24, 0, 0, 0, 0, time.UTC)
+	rule.Until = &until
+
+	clone := rule.Clone()
+	assert.Equal(t, rule, clone)
+
+	*clone.Count = 5
+	*clone.Until = until.AddDate(1, 0, 0)
+	clone.Weekday[0].Weekday = WeekdaySunday
+	clone.Month[0] = 12
+	clone.Monthday[0] = 3
+	clone.YearDay[0] = 200
+	clone.SetPos[0] = 1
+	clone.Hour[0] = 10
+	clone.OtherParts["X-NAME"] = "B"
+	assert.Equal(t, 10, *rule.Count)
+	assert.Equal(t, until, *rule.Until)
+	assert.Equal(t, ByDay{Weekday: WeekdayFriday, Interval: 1, HasOrdinal: true}, rule.Weekday[0])
+	assert.Equal(t, []int{1, 6}, rule.Month)
+	assert.Equal(t, []int{2}, rule.Monthday)
+	assert.Equal(t, []int{100}, rule.YearDay)
+	assert.Equal(t, []int{-1}, rule.SetPos)
+	assert.Equal(t, []int{9}, rule.Hour)
+	assert.Equal(t, map[string]string{"X-NAME": "A"}, rule.OtherParts)
+
+	assert.Nil(t, (*RRule)(nil).Clone())
+}
+
+func TestRRuleEqual(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     string
+		b     string
+		equal bool
+	}{
+		{name: "identical", a: "FREQ=WEEKLY;BYDAY=MO,WE", b: "FREQ=WEEKLY;BYDAY=MO,WE", equal: true},
+		{name: "BYDAY in another order", a: "FREQ=WEEKLY;BYDAY=MO,WE", b: "FREQ=WEEKLY;BYDAY=WE,MO", equal: true},
+		{name: "BYMONTH in another order", a: "FREQ=YEARLY;BYMONTH=1,6", b: "FREQ=YEARLY;BYMONTH=6,1", equal: true},
+		{name: "default interval", a: "FREQ=DAILY", b: "FREQ=DAILY;INTERVAL=1", equal: true},
+		{name: "different frequency", a: "FREQ=DAILY", b: "FREQ=WEEKLY", equal: false},
+		{name: "different interval", a: "FREQ=DAILY;INTERVAL=2", b: "FREQ=DAILY", equal: false},
+		{name: "UNTIL as a date and a time", a: "FREQ=DAILY;UNTIL=20300101", b: "FREQ=DAILY;UNTIL=20300101T000000Z", equal: false},
+		{name: "COUNT on one side", a: "FREQ=DAILY;COUNT=3", b: "FREQ=DAILY", equal: false},
+		{name: "different BYDAY ordinal", a: "FREQ=MONTHLY;BYDAY=1FR", b: "FREQ=MONTHLY;BYDAY=-1FR", equal: false},
+		{name: "repeated BYDAY value", a: "FREQ=WEEKLY;BYDAY=MO,MO", b: "FREQ=WEEKLY;BYDAY=MO,WE", equal: false},
+		{name: "first weekday and every weekday", a: "FREQ=MONTHLY;BYDAY=1MO", b: "FREQ=MONTHLY;BYDAY=MO", equal: false},
+		{name: "BYSETPOS on one side", a: "FREQ=MONTHLY;BYDAY=MO,FR;BYSETPOS=-1", b: "FREQ=MONTHLY;BYDAY=MO,FR", equal: false},
+		{name: "different BYHOUR", a: "FREQ=DAILY;BYHOUR=9", b: "FREQ=DAILY;BYHOUR=17", equal: false},
+		{name: "BYMINUTE in another order", a: "FREQ=DAILY;BYMINUTE=0,30", b: "FREQ=DAILY;BYMINUTE=30,0", equal: true},
+		{name: "default week start", a: "FREQ=WEEKLY;WKST=MO", b: "FREQ=WEEKLY", equal: true},
+		{name: "different week start", a: "FREQ=WEEKLY;WKST=SU", b: "FREQ=WEEKLY", equal: false},
+		{name: "different extension part", a: "FREQ=YEARLY;RSCALE=HEBREW", b: "FREQ=YEARLY;RSCALE=GREGORIAN", equal: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := ParseRRule(test.a)
+			assert.NoError(t, err)
+			b, err := ParseRRule(test.b)
+			assert.NoError(t, err)
+			assert.Equal(t, test.equal, a.Equal(b))
+			assert.Equal(t, test.equal, b.Equal(a))
+		})
+	}
+
+	until := time.Date(2025, time.December, 24, 0, 0, 0, 0, time.UTC)
+	untilElsewhere := until.In(time.FixedZone("UTC-5", -5*60*60))
+	assert.True(t, (&RRule{Frequency: FrequencyDaily, Until: &until}).Equal(&RRule{Frequency: FrequencyDaily, Until: &untilElsewhere}))
+
+	assert.True(t, (*RRule)(nil).Equal(nil))
+	assert.False(t, (*RRule)(nil).Equal(&RRule{Frequency: FrequencyDaily}))
+}
+
+func TestRRuleString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "frequency only", input: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "default interval is left out", input: "FREQ=DAILY;INTERVAL=1;COUNT=10", want: "FREQ=DAILY;COUNT=10"},
+		{name: "interval", input: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR"},
+		{name: "until", input: "FREQ=WEEKLY;UNTIL=19971224T000000Z", want: "FREQ=WEEKLY;UNTIL=19971224T000000Z"},
+		{name: "until a date", input: "FREQ=YEARLY;UNTIL=20300101", want: "FREQ=YEARLY;UNTIL=20300101"},
+		{name: "until a floating time", input: "FREQ=YEARLY;UNTIL=20300101T020000", want: "FREQ=YEARLY;UNTIL=20300101T020000"},
+		{name: "ordinal weekdays", input: "FREQ=MONTHLY;BYDAY=-1SU,2MO", want: "FREQ=MONTHLY;BYDAY=-1SU,2MO"},
+		{name: "first weekday", input: "FREQ=MONTHLY;BYDAY=1MO,TU", want: "FREQ=MONTHLY;BYDAY=1MO,TU"},
+		{
+			name:  "last weekday of the month",
+			input: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			want:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		},
+		{
+			name:  "time of day and week parts",
+			input: "FREQ=YEARLY;WKST=SU;BYSETPOS=1;BYWEEKNO=20;BYHOUR=9,17;BYMINUTE=0,30;BYSECOND=15",
+			want:  "FREQ=YEARLY;BYSECOND=15;BYMINUTE=0,30;BYHOUR=9,17;BYWEEKNO=20;BYSETPOS=1;WKST=SU",
+		},
+		{name: "other rule parts", input: "FREQ=MONTHLY;SKIP=FORWARD;RSCALE=HEBREW", want: "FREQ=MONTHLY;RSCALE=HEBREW;SKIP=FORWARD"},
+		{
+			name:  "every list",
+			input: "FREQ=YEARLY;BYMONTH=1,6;BYMONTHDAY=-3,10;BYYEARDAY=100",
+			want:  "FREQ=YEARLY;BYMONTHDAY=-3,10;BYYEARDAY=100;BYMONTH=1,6",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, err := ParseRRule(test.input)
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, rule.String())
+
+			reparsed, err := ParseRRule(rule.String())
+			assert.NoError(t, err)
+			assert.Equal(t, rule, reparsed)
+		})
+	}
+}
@@ -3,11 +3,13 @@ package test
 import (
 	_ "embed"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/michael-gallo/simpleical/model"
 	"github.com/michael-gallo/simpleical/parse"
+	"github.com/michael-gallo/simpleical/rrule"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +22,12 @@ var (
 	testEmptyCalendarInput string
 	//go:embed test_data/calendar/valid_calendar_trailing_whitespace.ical
 	testTrailingWithSpaceInput string
+	//go:embed test_data/calendar/valid_calendar_folded.ical
+	testFoldedCalendarInput string
+	//go:embed test_data/calendar/valid_calendar_with_extra_properties.ical
+	testExtraPropertiesCalendarInput string
+	//go:embed test_data/calendar/valid_calendar_with_unknown_components.ical
+	testUnknownComponentsCalendarInput string
 	//go:embed test_data/calendar/no_begin_calendar.ical
 	testInvalidBeginCalendarInput string
 	//go:embed test_data/calendar/no_end_calendar.ical
@@ -30,6 +38,28 @@ var (
 	testCalendarMissingVersionInput string
 	//go:embed test_data/calendar/calendar_missing_prodid.ical
 	testCalendarMissingProdIDInput string
+	//go:embed test_data/calendar/calendar_mismatched_unknown_component.ical
+	testCalendarMismatchedUnknownComponentInput string
+	//go:embed test_data/calendar/calendar_standard_outside_timezone.ical
+	testCalendarStandardOutsideTimezoneInput string
+	//go:embed test_data/calendar/calendar_stray_end_alarm.ical
+	testCalendarStrayEndAlarmInput string
+	//go:embed test_data/calendar/calendar_end_calendar_inside_event.ical
+	testCalendarEndCalendarInsideEventInput string
+	//go:embed test_data/calendar/valid_multiple_calendars.ical
+	testMultipleCalendarsInput string
+	//go:embed test_data/calendar/multiple_calendars_second_invalid.ical
+	testMultipleCalendarsSecondInvalidInput string
+	//go:embed test_data/calendar/multiple_calendars_tzid_of_previous.ical
+	testMultipleCalendarsTZIDOfPreviousInput string
+	//go:embed test_data/calendar/calendar_event_inside_event.ical
+	testCalendarEventInsideEventInput string
+	//go:embed test_data/calendar/valid_calendar_mixed_case.ical
+	testMixedCaseCalendarInput string
+	//go:embed test_data/calendar/valid_calendar_for_equal.ical
+	testCalendarForEqualInput string
+	//go:embed test_data/calendar/valid_calendar_for_equal_reordered.ical
+	testCalendarForEqualReorderedInput string
 )
 
 func TestParseCalendarSuccess(t *testing.T) {
@@ -50,12 +80,12 @@ func TestParseCalendarSuccess(t *testing.T) {
 					{
 						DTStamp:     time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
 						UID:         "13235@example.com",
-						Comment:     []string{"I Am", "A Comment"},
-						Start:       time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC),
-						End:         time.Date(2025, time.September, 28, 20, 30, 0, 0, time.UTC),
-						Summary:     "Event Summary",
-						Description: "Event Description",
-						Location:    "555 Fake Street",
+						Comment:     []model.Text{{Value: "I Am"}, {Value: "A Comment"}},
+						Start:       model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						End:         model.DateTime{Time: time.Date(2025, time.September, 28, 20, 30, 0, 0, time.UTC)},
+						Summary:     model.Text{Value: "Event Summary"},
+						Description: model.Text{Value: "Event Description"},
+						Location:    model.Text{Value: "555 Fake Street"},
 						Organizer: &model.Organizer{
 							CommonName: "Org",
 							CalAddress: &url.URL{Scheme: "mailto", Opaque: "hello@world"},
@@ -63,10 +93,10 @@ func TestParseCalendarSuccess(t *testing.T) {
 						Status:       model.EventStatusConfirmed,
 						Sequence:     1,
 						Transp:       model.EventTranspOpaque,
-						Contacts:     []string{"Jim Dolittle, ABC Industries, +1-919-555-1234"},
+						Contacts:     []model.Text{{Value: "Jim Dolittle, ABC Industries, +1-919-555-1234"}},
 						LastModified: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
 						Categories:   []string{"first", "second", "third"},
-						Geo:          []float64{37.386013, -122.082932},
+						Geo:          &model.Geo{Lat: 37.386013, Lon: -122.082932},
 					},
 				},
 				TimeZones: []model.TimeZone{
@@ -74,8 +104,8 @@ func TestParseCalendarSuccess(t *testing.T) {
 						TimeZoneID: "America/Detroit",
 						Standard: []model.TimeZoneProperty{
 							{
-								TimeZoneOffsetFrom: "+0000",
-								TimeZoneOffsetTo:   "+0000",
+								TimeZoneOffsetFrom: 0,
+								TimeZoneOffsetTo:   0,
 								DTStart:            time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
 							},
 						},
@@ -102,6 +132,169 @@ func TestParseCalendarSuccess(t *testing.T) {
 				Events:  nil,
 			},
 		},
+		{
+			name:  "Calendar with folded lines and CRLF line endings",
+			input: testFoldedCalendarInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Event//Event Calendar//EN",
+				Version: "2.0",
+				Events: []model.Event{
+					{
+						DTStamp:     time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+						UID:         "13235@example.com",
+						Start:       model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						Summary:     model.Text{Value: "Café meeting"},
+						Description: model.Text{Value: "This description is long enough that the producer decided to fold it over several lines, which is what real calendar feeds do"},
+					},
+				},
+			},
+		},
+		{
+			name:  "Calendar with X- and IANA properties",
+			input: testExtraPropertiesCalendarInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Microsoft Corporation//Outlook 16.0 MIMEDIR//EN",
+				Version: "2.0",
+				XProp: map[string][]model.Property{
+					"X-WR-CALNAME":  {{Name: "X-WR-CALNAME", Value: "Team Calendar"}},
+					"X-WR-TIMEZONE": {{Name: "X-WR-TIMEZONE", Value: "America/New_York"}},
+				},
+				IANAProp: map[string][]model.Property{
+					"COLOR": {{Name: "COLOR", Value: "red"}},
+				},
+				TimeZones: []model.TimeZone{
+					{
+						TimeZoneID: "America/New_York",
+						XProp: map[string][]model.Property{
+							"X-LIC-LOCATION": {{Name: "X-LIC-LOCATION", Value: "America/New_York"}},
+						},
+						Standard: []model.TimeZoneProperty{
+							{
+								TimeZoneOffsetFrom: -4 * 60 * 60,
+								TimeZoneOffsetTo:   -5 * 60 * 60,
+								DTStart:            time.Date(1970, time.November, 1, 2, 0, 0, 0, time.UTC),
+								XProp: map[string][]model.Property{
+									"X-TZINFO": {{Name: "X-TZINFO", Value: "Eastern Standard Time"}},
+								},
+							},
+						},
+					},
+				},
+				Events: []model.Event{
+					{
+						UID:     "13235@example.com",
+						DTStamp: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+						Start:   model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						XProp: map[string][]model.Property{
+							"X-MICROSOFT-CDO-BUSYSTATUS":     {{Name: "X-MICROSOFT-CDO-BUSYSTATUS", Value: "BUSY"}},
+							"X-MICROSOFT-CDO-INTENDEDSTATUS": {{Name: "X-MICROSOFT-CDO-INTENDEDSTATUS", Value: "BUSY"}},
+							"X-ALT-DESC": {
+								{Name: "X-ALT-DESC", Params: model.Parameters{"FMTTYPE": {"text/html"}}, Value: "<p>First</p>"},
+								{Name: "X-ALT-DESC", Params: model.Parameters{"FMTTYPE": {"text/html"}}, Value: "<p>Second</p>"},
+							},
+						},
+						IANAProp: map[string][]model.Property{
+							"STYLED-DESCRIPTION": {{Name: "STYLED-DESCRIPTION", Params: model.Parameters{"VALUE": {"TEXT"}}, Value: "Styled"}},
+						},
+						Alarms: []model.Alarm{
+							{
+								Action:  model.AlarmActionAudio,
+								Trigger: model.Trigger{Duration: -15 * time.Minute, Related: model.TriggerRelatedStart},
+								XProp: map[string][]model.Property{
+									"X-WR-ALARMUID": {{Name: "X-WR-ALARMUID", Value: "alarm-1"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Calendar with mixed case names and enumerated values",
+			input: testMixedCaseCalendarInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Example//Mixed Case//EN",
+				Version: "2.0",
+				Events: []model.Event{
+					{
+						UID:     "13235@example.com",
+						DTStamp: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+						Start:   model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						Summary: model.Text{Value: "Lower case names"},
+						Status:  model.EventStatusConfirmed,
+						Transp:  model.EventTranspTransparent,
+						RRule: &rrule.RRule{
+							Frequency: rrule.FrequencyWeekly,
+							Interval:  1,
+							Weekday:   []rrule.ByDay{{Weekday: rrule.WeekdayMonday, Interval: 1}, {Weekday: rrule.WeekdayFriday, Interval: 1}},
+						},
+						XProp: map[string][]model.Property{
+							"X-CUSTOM": {{Name: "X-CUSTOM", Value: "value"}},
+						},
+						Alarms: []model.Alarm{
+							{
+								Action:      model.AlarmActionDisplay,
+								Trigger:     model.Trigger{Duration: -15 * time.Minute, Related: model.TriggerRelatedStart},
+								Description: []model.Text{{Value: "Reminder"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Calendar with unknown and extension components",
+			input: testUnknownComponentsCalendarInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Example//Availability//EN",
+				Version: "2.0",
+				Events: []model.Event{
+					{
+						UID:     "13235@example.com",
+						DTStamp: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+						Start:   model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						OtherComponents: []model.Component{
+							{
+								Name:       "X-CUSTOM-DATA",
+								Properties: []model.Property{{Name: "X-KEY", Value: "value"}},
+							},
+						},
+					},
+				},
+				OtherComponents: []model.Component{
+					{
+						Name: "VAVAILABILITY",
+						Properties: []model.Property{
+							{Name: "UID", Value: "availability-1@example.com"},
+							{Name: "DTSTART", Params: model.Parameters{"TZID": {"America/Montreal"}}, Value: "20111002T000000"},
+						},
+						Components: []model.Component{
+							{
+								Name: "AVAILABLE",
+								Properties: []model.Property{
+									{Name: "UID", Value: "available-1@example.com"},
+									{Name: "SUMMARY", Value: "Monday to Friday from 9:00 to 17:00"},
+									{Name: "RRULE", Value: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+								},
+							},
+						},
+					},
+					{
+						Name:       "VPOLL",
+						Properties: []model.Property{{Name: "UID", Value: "poll-1@example.com"}},
+						Components: []model.Component{
+							{
+								Name: "VEVENT",
+								Properties: []model.Property{
+									{Name: "UID", Value: "13235@example.com"},
+									{Name: "POLL-ITEM-ID", Value: "1"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:  "Calendar with trailing space",
 			input: testTrailingWithSpaceInput,
@@ -147,6 +340,26 @@ func TestParseCalendarError(t *testing.T) {
 			name:  "Calendar missing PRODID property",
 			input: testCalendarMissingProdIDInput,
 		},
+		{
+			name:  "Unknown component closed with a different name",
+			input: testCalendarMismatchedUnknownComponentInput,
+		},
+		{
+			name:  "STANDARD outside of a VTIMEZONE",
+			input: testCalendarStandardOutsideTimezoneInput,
+		},
+		{
+			name:  "END:VALARM without a matching BEGIN:VALARM",
+			input: testCalendarStrayEndAlarmInput,
+		},
+		{
+			name:  "END:VCALENDAR while a VEVENT is open",
+			input: testCalendarEndCalendarInsideEventInput,
+		},
+		{
+			name:  "VEVENT nested in a VEVENT",
+			input: testCalendarEventInsideEventInput,
+		},
 		{
 			name:  "Empty input",
 			input: "",
@@ -160,3 +373,459 @@ func TestParseCalendarError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseErrorLocation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedError error
+		expected      parse.ParseError
+	}{
+		{
+			name:          "Invalid property value",
+			input:         testIcalInvalidStartInput,
+			expectedError: parse.ErrParseErrorInComponent,
+			expected: parse.ParseError{
+				Severity: parse.SeverityError,
+				Line:     16,
+				Column:   9,
+				Offset:   272,
+				Path:     "VCALENDAR/VEVENT[0]",
+				Property: "DTSTART",
+				Raw:      "DTSTART:IAMINVALID",
+			},
+		},
+		{
+			name:          "Missing required property",
+			input:         testEventAlarmMissingActionInput,
+			expectedError: parse.ErrMissingAlarmActionProperty,
+			expected: parse.ParseError{
+				Severity: parse.SeverityError,
+				Line:     13,
+				Column:   1,
+				Offset:   276,
+				Path:     "VCALENDAR/VEVENT[0]/VALARM[0]",
+				Property: "END",
+				Raw:      "END:VALARM",
+			},
+		},
+		{
+			name:          "END without a matching BEGIN",
+			input:         testCalendarStrayEndAlarmInput,
+			expectedError: parse.ErrTemplateInvalidEndBlock,
+			expected: parse.ParseError{
+				Severity: parse.SeverityWarning,
+				Line:     8,
+				Column:   1,
+				Offset:   145,
+				Path:     "VCALENDAR/VEVENT[0]",
+				Property: "END",
+				Raw:      "END:VALARM",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, err := parse.IcalString(tc.input)
+			assert.Nil(t, calendar)
+			assert.ErrorIs(t, err, tc.expectedError)
+
+			var parseError *parse.ParseError
+			if assert.ErrorAs(t, err, &parseError) {
+				parseError.Err = nil
+				assert.Equal(t, tc.expected, *parseError)
+			}
+		})
+	}
+}
+
+func TestParseErrorSentinel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedError error
+	}{
+		{
+			name:          "Invalid RRULE",
+			input:         testIcalInvalidRRuleInput,
+			expectedError: parse.ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Invalid ORGANIZER address",
+			input:         testIcalInvalidOrganizerInput,
+			expectedError: parse.ErrInvalidPropertyValue,
+		},
+		{
+			name:          "Duplicate GEO",
+			input:         testIcalDuplicateGeoInput,
+			expectedError: parse.ErrDuplicatePropertyInComponent,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, err := parse.IcalString(tc.input)
+			assert.Nil(t, calendar)
+			assert.ErrorIs(t, err, tc.expectedError)
+
+			var parseError *parse.ParseError
+			assert.ErrorAs(t, err, &parseError)
+		})
+	}
+}
+
+func TestParseMultipleCalendars(t *testing.T) {
+	expected := []*model.Calendar{
+		{
+			Version: "2.0",
+			ProdID:  "-//Example//First//EN",
+			Method:  "REQUEST",
+			Events: []model.Event{
+				{
+					UID:     "first@example.com",
+					DTStamp: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+					Start:   model.DateTime{Time: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)},
+					Summary: model.Text{Value: "First calendar event"},
+				},
+			},
+		},
+		{
+			Version: "2.0",
+			ProdID:  "-//Example//Second//EN",
+			Method:  "CANCEL",
+			Events: []model.Event{
+				{
+					UID:     "second@example.com",
+					DTStamp: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+					Start:   model.DateTime{Time: time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC)},
+					Summary: model.Text{Value: "Second calendar event"},
+				},
+			},
+		},
+	}
+	calendars, err := parse.IcalReaderAll(strings.NewReader(testMultipleCalendarsInput))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, calendars)
+
+	// A single calendar stream is returned as a slice of one.
+	calendars, err = parse.IcalReaderAll(strings.NewReader(testValidCalendarInput))
+	assert.NoError(t, err)
+	assert.Len(t, calendars, 1)
+
+	// The single calendar functions still reject anything after END:VCALENDAR.
+	calendar, err := parse.IcalString(testMultipleCalendarsInput)
+	assert.Nil(t, calendar)
+	assert.ErrorIs(t, err, parse.ErrContentAfterEndBlock)
+}
+
+func TestParseMultipleCalendarsError(t *testing.T) {
+	calendars, err := parse.IcalReaderAll(strings.NewReader(testMultipleCalendarsSecondInvalidInput))
+	assert.Nil(t, calendars)
+	assert.ErrorIs(t, err, parse.ErrMissingEventUIDProperty)
+
+	var parseError *parse.ParseError
+	if assert.ErrorAs(t, err, &parseError) {
+		assert.Equal(t, "VCALENDAR[1]/VEVENT[0]", parseError.Path)
+		assert.Equal(t, 11, parseError.Line)
+	}
+}
+
+func TestParseMultipleCalendarsTimeZones(t *testing.T) {
+	// The second calendar uses a TZID only the first one defines.
+	calendars, err := parse.IcalReaderAll(strings.NewReader(testMultipleCalendarsTZIDOfPreviousInput), parse.WithRequireKnownTimeZones())
+	assert.Nil(t, calendars)
+	assert.ErrorIs(t, err, parse.ErrUnknownTimeZone)
+
+	var parseError *parse.ParseError
+	if assert.ErrorAs(t, err, &parseError) {
+		assert.Equal(t, "VCALENDAR[1]/VEVENT[0]", parseError.Path)
+		assert.Equal(t, 24, parseError.Line)
+	}
+}
+
+func TestCalendarComponents(t *testing.T) {
+	start := model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)}
+	calendar := model.Calendar{
+		Events: []model.Event{
+			{UID: "meeting@example.com", Start: start, RecurrenceID: start},
+			{UID: "meeting@example.com", Start: start, Summary: model.Text{Value: "Weekly meeting"}},
+		},
+		Todos:    []model.Todo{{UID: "task@example.com", DTStart: start, Sequence: 2}},
+		Journals: []model.Journal{{UID: "notes@example.com", DTStart: start}},
+	}
+
+	var names []string
+	for component := range calendar.Components() {
+		names = append(names, component.ComponentName()+" "+component.GetUID())
+		assert.Equal(t, start, component.GetStart())
+	}
+	assert.Equal(t, []string{
+		"VEVENT meeting@example.com",
+		"VEVENT meeting@example.com",
+		"VTODO task@example.com",
+		"VJOURNAL notes@example.com",
+	}, names)
+
+	for component := range calendar.Components() {
+		if _, ok := component.(*model.Todo); ok {
+			break
+		}
+		component.(*model.Event).Sequence++
+	}
+	assert.Equal(t, 1, calendar.Events[0].Sequence)
+	assert.Equal(t, 1, calendar.Events[1].Sequence)
+	assert.Equal(t, 2, calendar.Todos[0].Sequence)
+
+	component, ok := calendar.ComponentByUID("meeting@example.com")
+	assert.True(t, ok)
+	assert.Same(t, &calendar.Events[1], component)
+
+	component, ok = calendar.ComponentByUID("notes@example.com")
+	assert.True(t, ok)
+	assert.Same(t, &calendar.Journals[0], component)
+
+	component, ok = calendar.ComponentByUID("missing@example.com")
+	assert.False(t, ok)
+	assert.Nil(t, component)
+}
+
+func TestCalendarClone(t *testing.T) {
+	for name, input := range map[string]string{
+		"all event properties": testEventAllPropertiesInput,
+		"full attendee":        testEventFullAttendeeInput,
+		"attachments":          testEventAttachmentsInput,
+		"timezone":             testIcalWithEventAndTimezoneInput,
+		"unknown components":   testUnknownComponentsCalendarInput,
+		"equal":                testCalendarForEqualInput,
+	} {
+		t.Run(name, func(t *testing.T) {
+			original, err := parse.IcalString(input)
+			assert.NoError(t, err)
+
+			clone := original.Clone()
+			assert.Equal(t, original, clone)
+			assert.True(t, original.Equal(clone))
+
+			mutateCalendar(clone)
+			expected, err := parse.IcalString(input)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, original)
+		})
+	}
+
+	assert.Nil(t, (*model.Calendar)(nil).Clone())
+}
+
+// mutateCalendar changes every slice, map and pointer reachable from calendar in place.
+func mutateCalendar(calendar *model.Calendar) {
+	otherURL, _ := url.Parse("mailto:other@example.com")
+	mutateProps := func(props map[string][]model.Property) {
+		for name := range props {
+			props[name][0].Value = "changed"
+			for param := range props[name][0].Params {
+				props[name][0].Params[param][0] = "changed"
+			}
+		}
+	}
+	var mutateComponent func(component *model.Component)
+	mutateComponent = func(component *model.Component) {
+		for i := range component.Properties {
+			component.Properties[i].Value = "changed"
+		}
+		for i := range component.Components {
+			mutateComponent(&component.Components[i])
+		}
+	}
+
+	mutateProps(calendar.XProp)
+	for i := range calendar.OtherComponents {
+		mutateComponent(&calendar.OtherComponents[i])
+	}
+	for i := range calendar.TimeZones {
+		timeZone := &calendar.TimeZones[i]
+		for j := range timeZone.Standard {
+			timeZone.Standard[j].TimeZoneOffsetTo++
+		}
+	}
+	for i := range calendar.Events {
+		event := &calendar.Events[i]
+		if event.Organizer != nil {
+			*event.Organizer.CalAddress = *otherURL
+		}
+		if event.Geo != nil {
+			event.Geo.Lat = 0
+		}
+		if event.RRule != nil {
+			if event.RRule.Count != nil {
+				*event.RRule.Count = 100
+			}
+			if len(event.RRule.Weekday) > 0 {
+				event.RRule.Weekday[0].Weekday = rrule.WeekdaySunday
+			}
+		}
+		for j := range event.Attendees {
+			*event.Attendees[j].CalAddress = *otherURL
+			if len(event.Attendees[j].Member) > 0 {
+				*event.Attendees[j].Member[0] = *otherURL
+			}
+			for param := range event.Attendees[j].OtherParams {
+				event.Attendees[j].OtherParams[param][0] = "changed"
+			}
+		}
+		for j := range event.Attach {
+			if event.Attach[j].URI != nil {
+				*event.Attach[j].URI = *otherURL
+			}
+		}
+		for j := range event.Categories {
+			event.Categories[j] = "changed"
+		}
+		for j := range event.Comment {
+			event.Comment[j].Value = "changed"
+		}
+		for j := range event.ExceptionDates {
+			event.ExceptionDates[j].Time = time.Time{}
+		}
+		for j := range event.RequestStatus {
+			event.RequestStatus[j].Code[0] = 5
+		}
+		for j := range event.Related {
+			event.Related[j].UID = "changed"
+		}
+		for j := range event.Alarms {
+			event.Alarms[j].Description[0].Value = "changed"
+		}
+		mutateProps(event.XProp)
+		mutateProps(event.IANAProp)
+	}
+}
+
+func TestCalendarEqual(t *testing.T) {
+	calendar, err := parse.IcalString(testCalendarForEqualInput)
+	assert.NoError(t, err)
+
+	reordered, err := parse.IcalString(testCalendarForEqualReorderedInput)
+	assert.NoError(t, err)
+	assert.True(t, calendar.Equal(reordered), "order, time zones and defaults do not matter")
+	assert.True(t, reordered.Equal(calendar))
+
+	raw, err := parse.IcalReaderWithOptions(strings.NewReader(testCalendarForEqualInput), parse.WithRawText())
+	assert.NoError(t, err)
+	assert.True(t, calendar.Equal(raw), "escaped and unescaped text are equal")
+
+	assert.True(t, (*model.Calendar)(nil).Equal(nil))
+	assert.False(t, calendar.Equal(nil))
+
+	equalCases := []struct {
+		name   string
+		mutate func(calendar, other *model.Calendar)
+	}{
+		{
+			name: "start in the UTC time zone",
+			mutate: func(_, other *model.Calendar) {
+				start := &other.Todos[0].DTStart
+				*start = model.DateTime{Time: start.Time.In(time.UTC), Kind: model.DateTimeLocal, TZID: "UTC"}
+			},
+		},
+		{
+			name:   "lower case action",
+			mutate: func(_, other *model.Calendar) { other.Events[0].Alarms[0].Action = "display" },
+		},
+		{
+			name: "lower case participation status",
+			mutate: func(_, other *model.Calendar) {
+				other.Events[0].Attendees[1].ParticipationStatus = "needs-action"
+			},
+		},
+		{
+			name: "parameter names in a different case",
+			mutate: func(calendar, other *model.Calendar) {
+				calendar.Events[0].XProp["X-COLOR"][0].Params = model.Parameters{"X-SHADE": {"dark", "light"}}
+				other.Events[0].XProp["X-COLOR"][0].Params = model.Parameters{"x-shade": {"dark"}, "X-Shade": {"light"}}
+			},
+		},
+	}
+
+	for _, tc := range equalCases {
+		t.Run(tc.name, func(t *testing.T) {
+			original, changed := calendar.Clone(), calendar.Clone()
+			tc.mutate(original, changed)
+			assert.True(t, original.Equal(changed))
+			assert.True(t, changed.Equal(original))
+		})
+	}
+
+	testCases := []struct {
+		name   string
+		mutate func(calendar *model.Calendar)
+	}{
+		{
+			name:   "summary with a backslash",
+			mutate: func(calendar *model.Calendar) { calendar.Events[0].Summary.Value = `Planning\\, budget` },
+		},
+		{
+			name: "start in an unresolved time zone",
+			mutate: func(calendar *model.Calendar) {
+				start := &calendar.Todos[0].DTStart
+				*start = model.DateTime{Time: start.Time, Kind: model.DateTimeLocal, TZID: "Mars/Olympus", Unresolved: true}
+			},
+		},
+		{
+			name:   "recurrence with a set position",
+			mutate: func(calendar *model.Calendar) { calendar.Events[0].RRule.SetPos = []int{-1} },
+		},
+		{
+			name:   "different summary",
+			mutate: func(calendar *model.Calendar) { calendar.Events[0].Summary.Value = "Planning" },
+		},
+		{
+			name: "floating start with the same wall clock time",
+			mutate: func(calendar *model.Calendar) {
+				start := &calendar.Events[0].Start
+				*start = model.DateTime{Time: start.Time.UTC(), Kind: model.DateTimeFloating}
+			},
+		},
+		{
+			name: "different participation status",
+			mutate: func(calendar *model.Calendar) {
+				calendar.Events[0].Attendees[1].ParticipationStatus = model.ParticipationStatusAccepted
+			},
+		},
+		{
+			name: "extra category",
+			mutate: func(calendar *model.Calendar) {
+				calendar.Events[0].Categories = append(calendar.Events[0].Categories, "Meeting")
+			},
+		},
+		{
+			name: "missing X- property value",
+			mutate: func(calendar *model.Calendar) {
+				calendar.Events[0].XProp["X-COLOR"] = calendar.Events[0].XProp["X-COLOR"][:1]
+			},
+		},
+		{
+			name:   "different recurrence day",
+			mutate: func(calendar *model.Calendar) { calendar.Events[0].RRule.Weekday[0].Weekday = rrule.WeekdayFriday },
+		},
+		{
+			name:   "alarm related to the end",
+			mutate: func(calendar *model.Calendar) { calendar.Events[0].Alarms[0].Trigger.Related = model.TriggerRelatedEnd },
+		},
+		{
+			name:   "child relation",
+			mutate: func(calendar *model.Calendar) { calendar.Events[0].Related[0].Type = model.RelationTypeChild },
+		},
+		{
+			name:   "missing todo",
+			mutate: func(calendar *model.Calendar) { calendar.Todos = nil },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := calendar.Clone()
+			tc.mutate(changed)
+			assert.False(t, calendar.Equal(changed))
+			assert.False(t, reordered.Equal(changed))
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderSuccess(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected func(calendar *model.Calendar) []model.TopLevelComponent
+	}{
+		{
+			name:  "Calendar with event and timezone",
+			input: testIcalWithEventAndTimezoneInput,
+			expected: func(calendar *model.Calendar) []model.TopLevelComponent {
+				return []model.TopLevelComponent{&calendar.TimeZones[0], &calendar.Events[0]}
+			},
+		},
+		{
+			name:  "Calendar with unknown and extension components",
+			input: testUnknownComponentsCalendarInput,
+			expected: func(calendar *model.Calendar) []model.TopLevelComponent {
+				return []model.TopLevelComponent{&calendar.OtherComponents[0], &calendar.Events[0], &calendar.OtherComponents[1]}
+			},
+		},
+		{
+			name:  "Calendar without components",
+			input: testEmptyCalendarInput,
+			expected: func(calendar *model.Calendar) []model.TopLevelComponent {
+				return nil
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The decoder must return the same components, in input order, as the non-streaming parser.
+			calendar, err := parse.IcalString(tc.input)
+			assert.NoError(t, err)
+
+			decoder := parse.NewDecoder(strings.NewReader(tc.input))
+			var components []model.TopLevelComponent
+			for component, err := range decoder.All() {
+				assert.NoError(t, err)
+				components = append(components, component)
+			}
+			assert.Equal(t, tc.expected(calendar), components)
+
+			// Only the calendar properties are kept by the decoder.
+			assert.Equal(t, calendar.Version, decoder.Calendar().Version)
+			assert.Equal(t, calendar.ProdID, decoder.Calendar().ProdID)
+			assert.Empty(t, decoder.Calendar().Events)
+			assert.Empty(t, decoder.Calendar().TimeZones)
+			assert.Empty(t, decoder.Calendar().OtherComponents)
+
+			component, err := decoder.Next()
+			assert.Nil(t, component)
+			assert.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestDecoderError(t *testing.T) {
+	decoder := parse.NewDecoder(strings.NewReader(testEventAlarmMissingActionInput))
+
+	component, err := decoder.Next()
+	assert.Nil(t, component)
+	assert.ErrorIs(t, err, parse.ErrMissingAlarmActionProperty)
+
+	// The error is sticky.
+	component, err = decoder.Next()
+	assert.Nil(t, component)
+	assert.ErrorIs(t, err, parse.ErrMissingAlarmActionProperty)
+}
+
+func TestDecoderContentAfterEnd(t *testing.T) {
+	decoder := parse.NewDecoder(strings.NewReader(testIcalWithEventAndTimezoneInput + "\nX-TRAILING:value\n"))
+
+	var components int
+	for {
+		component, err := decoder.Next()
+		if err != nil {
+			assert.ErrorIs(t, err, parse.ErrContentAfterEndBlock)
+			break
+		}
+		assert.NotNil(t, component)
+		components++
+	}
+	assert.Equal(t, 2, components, "components before END:VCALENDAR are returned before the error")
+}
@@ -0,0 +1,366 @@
+package test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michael-gallo/simpleical/encode"
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/parse"
+	"github.com/michael-gallo/simpleical/rrule"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "calendar with event and timezone", input: testIcalWithEventAndTimezoneInput},
+		{name: "folded calendar", input: testFoldedCalendarInput},
+		{name: "extra properties", input: testExtraPropertiesCalendarInput},
+		{name: "unknown components", input: testUnknownComponentsCalendarInput},
+		{name: "full organizer", input: testIcalFullOrganizerInput},
+		{name: "event with alarm", input: testEventWithAlarmInput},
+		{name: "event with rrule", input: testEventWithRRuleInput},
+		{name: "escaped text", input: testEventEscapedTextInput},
+		{name: "parameters", input: testEventParametersInput},
+		{name: "all event properties", input: testEventAllPropertiesInput},
+		{name: "full attendee", input: testEventFullAttendeeInput},
+		{name: "alarm triggers", input: testEventAlarmTriggersInput},
+		{name: "attachments", input: testEventAttachmentsInput},
+		{name: "localized text", input: testEventLocalizedTextInput},
+		{name: "journal", input: testJournalInput},
+		{name: "journal with alarm", input: testJournalWithAlarmInput},
+		{name: "timezone", input: testTimezoneInput},
+		{name: "dates and TZIDs", input: testTimezoneDatesAndTZIDsInput},
+		{name: "todo", input: testTodoInput},
+		{name: "todo hierarchy", input: testTodoHierarchyInput},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, err := parse.IcalString(tc.input)
+			assert.NoError(t, err)
+
+			var output strings.Builder
+			assert.NoError(t, encode.Encode(&output, calendar))
+			assertContentLines(t, output.String())
+
+			reparsed, err := parse.IcalString(output.String())
+			assert.NoError(t, err)
+			assert.True(t, calendar.Equal(reparsed), "re-encoded calendar differs:\n%s", output.String())
+		})
+	}
+}
+
+// assertContentLines checks that every line of an encoded calendar ends in CRLF and is at most 75 octets long.
+func assertContentLines(t *testing.T, output string) {
+	t.Helper()
+	assert.True(t, strings.HasSuffix(output, "\r\n"))
+	for line := range strings.SplitSeq(strings.TrimSuffix(output, "\r\n"), "\r\n") {
+		assert.NotContains(t, line, "\n")
+		assert.NotContains(t, line, "\r")
+		assert.LessOrEqual(t, len(line), 75, line)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	detroit, err := time.LoadLocation("America/Detroit")
+	assert.NoError(t, err)
+	count := 4
+	calendar := &model.Calendar{
+		Version: "2.0",
+		ProdID:  "-//Event//Event Calendar//EN",
+		XProp: map[string][]model.Property{
+			"X-WR-CALNAME": {{Name: "X-WR-CALNAME", Value: "Team"}},
+		},
+		Events: []model.Event{
+			{
+				UID:     "meeting-1@example.com",
+				DTStamp: time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+				Start: model.DateTime{
+					Time: time.Date(2025, time.January, 6, 10, 0, 0, 0, detroit),
+					Kind: model.DateTimeLocal,
+					TZID: "America/Detroit",
+				},
+				Duration:    90 * time.Minute,
+				Summary:     model.Text{Value: "Café planning; budget, review", Language: "fr"},
+				Description: model.Text{Value: "Agenda:\n1. Budget\n2. Review of the quarterly numbers with l'équipe du café"},
+				Organizer: &model.Organizer{
+					CommonName: "Doe, Jane",
+					CalAddress: &url.URL{Scheme: "mailto", Opaque: "jane@example.com"},
+				},
+				RRule: &rrule.RRule{Frequency: rrule.FrequencyWeekly, Interval: 1, Count: &count},
+				Attendees: []model.Attendee{
+					{
+						CalAddress:          &url.URL{Scheme: "mailto", Opaque: "john@example.com"},
+						Role:                model.AttendeeRoleOptional,
+						RSVP:                true,
+						DelegatedFrom:       []*url.URL{{Scheme: "mailto", Opaque: "boss@example.com"}},
+						ParticipationStatus: model.ParticipationStatusNeedsAction,
+					},
+				},
+				Attach:         []model.Attachment{model.NewInlineAttachment([]byte("Hello, iCalendar!"), "text/plain")},
+				Categories:     []string{"Work", "Smith, John"},
+				ExceptionDates: []model.DateTime{{Time: time.Date(2025, time.January, 13, 0, 0, 0, 0, time.UTC), Kind: model.DateTimeDate}},
+				Alarms: []model.Alarm{
+					{
+						Action:      model.AlarmActionDisplay,
+						Trigger:     model.Trigger{Duration: -15 * time.Minute, Related: model.TriggerRelatedStart},
+						Description: []model.Text{{Value: "Reminder"}},
+					},
+				},
+			},
+		},
+		TimeZones: []model.TimeZone{
+			{
+				TimeZoneID: "America/Detroit",
+				Standard: []model.TimeZoneProperty{
+					{
+						DTStart:            time.Date(2007, time.November, 4, 2, 0, 0, 0, time.UTC),
+						TimeZoneOffsetFrom: -4 * 60 * 60,
+						TimeZoneOffsetTo:   -5 * 60 * 60,
+						TimeZoneName:       []string{"EST"},
+						RRule: &rrule.RRule{
+							Frequency: rrule.FrequencyYearly,
+							Interval:  1,
+							Month:     []int{11},
+							Weekday:   []rrule.ByDay{{Weekday: rrule.WeekdaySunday, Interval: 1}},
+						},
+					},
+				},
+			},
+		},
+		FreeBusys: []model.FreeBusy{
+			{
+				UID:     "freebusy-1@example.com",
+				DTStamp: time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+				DTStart: time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC),
+				FreeBusy: []model.FreeBusyTime{
+					{Start: time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC), End: time.Date(2025, time.January, 6, 10, 0, 0, 0, time.UTC), Status: model.FreeBusyStatusBusy},
+					{Start: time.Date(2025, time.January, 6, 11, 0, 0, 0, time.UTC), End: time.Date(2025, time.January, 6, 12, 0, 0, 0, time.UTC), Status: model.FreeBusyStatusBusyTentative},
+				},
+			},
+		},
+	}
+
+	expected := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"PRODID:-//Event//Event Calendar//EN",
+		"VERSION:2.0",
+		"X-WR-CALNAME:Team",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/Detroit",
+		"BEGIN:STANDARD",
+		"DTSTART:20071104T020000",
+		"TZOFFSETTO:-0500",
+		"TZOFFSETFROM:-0400",
+		"RRULE:FREQ=YEARLY;BYDAY=SU;BYMONTH=11",
+		"TZNAME:EST",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"DTSTAMP:20250101T120000Z",
+		"UID:meeting-1@example.com",
+		"DTSTART;TZID=America/Detroit:20250106T100000",
+		// The fold would split the é, so it moves one octet back.
+		"DESCRIPTION:Agenda:\\n1. Budget\\n2. Review of the quarterly numbers with l'",
+		" équipe du café",
+		"ORGANIZER;CN=\"Doe, Jane\":mailto:jane@example.com",
+		"SUMMARY;LANGUAGE=fr:Café planning\\; budget\\, review",
+		"RRULE:FREQ=WEEKLY;COUNT=4",
+		"DURATION:PT1H30M",
+		"ATTACH;FMTTYPE=text/plain;ENCODING=BASE64;VALUE=BINARY:SGVsbG8sIGlDYWxlbmRh",
+		" ciE=",
+		"ATTENDEE;ROLE=OPT-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE;DELEGATED-FRO",
+		" M=\"mailto:boss@example.com\":mailto:john@example.com",
+		"CATEGORIES:Work,Smith\\, John",
+		"EXDATE;VALUE=DATE:20250113",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"TRIGGER:-PT15M",
+		"DESCRIPTION:Reminder",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VFREEBUSY",
+		"DTSTAMP:20250101T120000Z",
+		"UID:freebusy-1@example.com",
+		"DTSTART:20250106T000000Z",
+		"FREEBUSY:20250106T090000Z/20250106T100000Z",
+		"FREEBUSY;FBTYPE=BUSY-TENTATIVE:20250106T110000Z/20250106T120000Z",
+		"END:VFREEBUSY",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	var output strings.Builder
+	assert.NoError(t, encode.Encode(&output, calendar))
+	assert.Equal(t, expected, output.String())
+
+	reparsed, err := parse.IcalString(output.String())
+	assert.NoError(t, err)
+	// The parser takes the type of a FREEBUSY period from the value only, not from the FBTYPE parameter.
+	assert.Equal(t, model.FreeBusyStatusBusy, reparsed.FreeBusys[0].FreeBusy[1].Status)
+	reparsed.FreeBusys[0].FreeBusy[1].Status = model.FreeBusyStatusBusyTentative
+	assert.True(t, calendar.Equal(reparsed))
+}
+
+func TestEncodeRecurrenceRules(t *testing.T) {
+	// Each rule is written back exactly as it was read.
+	rules := []string{
+		"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		"FREQ=MONTHLY;COUNT=10;BYDAY=1FR",
+		"FREQ=YEARLY;UNTIL=20300101",
+		"FREQ=DAILY;UNTIL=20300101T090000",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;WKST=SU",
+		"FREQ=DAILY;BYMINUTE=0,20,40;BYHOUR=9,10,11",
+		"FREQ=YEARLY;BYDAY=MO;BYWEEKNO=20",
+		"FREQ=MONTHLY;RSCALE=HEBREW;SKIP=FORWARD",
+	}
+	for _, rule := range rules {
+		t.Run(rule, func(t *testing.T) {
+			input := strings.Join([]string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//Event//Event Calendar//EN",
+				"BEGIN:VEVENT",
+				"UID:rule@example.com",
+				"DTSTAMP:20250101T120000Z",
+				"DTSTART;VALUE=DATE:20250101",
+				"RRULE:" + rule,
+				"END:VEVENT",
+				"END:VCALENDAR",
+			}, "\r\n")
+			calendar, err := parse.IcalString(input)
+			assert.NoError(t, err)
+
+			var output strings.Builder
+			assert.NoError(t, encode.Encode(&output, calendar))
+			assert.Contains(t, output.String(), "\r\nRRULE:"+rule+"\r\n")
+		})
+	}
+}
+
+func TestEncodeLineBreaks(t *testing.T) {
+	calendar := &model.Calendar{
+		Version: "2.0",
+		ProdID:  "-//Event//Event Calendar//EN",
+		Events: []model.Event{
+			{
+				UID:     "meeting-1@example.com\r\nX-INJECTED:uid",
+				DTStamp: time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+				Start:   model.DateTime{Time: time.Date(2025, time.January, 6, 15, 0, 0, 0, time.UTC)},
+				Summary: model.Text{Value: "Planning\r\nX-INJECTED:summary"},
+				Organizer: &model.Organizer{
+					CommonName: "Jane\r\nX-INJECTED:cn",
+					CalAddress: &url.URL{Scheme: "mailto", Opaque: "jane@example.com"},
+				},
+			},
+		},
+	}
+
+	var output strings.Builder
+	assert.NoError(t, encode.Encode(&output, calendar))
+	assertContentLines(t, output.String())
+	assert.NotContains(t, output.String(), "\r\nX-INJECTED")
+
+	reparsed, err := parse.IcalString(output.String())
+	assert.NoError(t, err)
+	event := reparsed.Events[0]
+	assert.Empty(t, event.XProp)
+	assert.Equal(t, "meeting-1@example.com\nX-INJECTED:uid", event.UID)
+	assert.Equal(t, "Planning\nX-INJECTED:summary", event.Summary.Value)
+	assert.Equal(t, "Jane\nX-INJECTED:cn", event.Organizer.CommonName)
+}
+
+func TestEncodeError(t *testing.T) {
+	validEvent := func() model.Event {
+		return model.Event{
+			UID:     "meeting-1@example.com",
+			DTStamp: time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+			Start:   model.DateTime{Time: time.Date(2025, time.January, 6, 15, 0, 0, 0, time.UTC)},
+		}
+	}
+	testCases := []struct {
+		name        string
+		mutate      func(event *model.Event)
+		expectedErr error
+	}{
+		{
+			name:        "line break in URL",
+			mutate:      func(event *model.Event) { event.URL = "https://example.com/\r\nX-INJECTED:url" },
+			expectedErr: encode.ErrLineBreakInValue,
+		},
+		{
+			name: "line break in calendar address",
+			mutate: func(event *model.Event) {
+				event.Attendees = []model.Attendee{{CalAddress: &url.URL{Scheme: "mailto", Opaque: "john@example.com\nX-INJECTED:attendee"}}}
+			},
+			expectedErr: encode.ErrLineBreakInValue,
+		},
+		{
+			name: "line break in X- property value",
+			mutate: func(event *model.Event) {
+				event.XProp = map[string][]model.Property{"X-COLOR": {{Name: "X-COLOR", Value: "red\r\nX-INJECTED:xprop"}}}
+			},
+			expectedErr: encode.ErrLineBreakInValue,
+		},
+		{
+			name: "line break in recurrence rule part",
+			mutate: func(event *model.Event) {
+				event.RRule = &rrule.RRule{Frequency: rrule.FrequencyDaily, OtherParts: map[string]string{"X-NAME": "a\r\nX-INJECTED:rrule"}}
+			},
+			expectedErr: encode.ErrLineBreakInValue,
+		},
+		{
+			name: "invalid property name",
+			mutate: func(event *model.Event) {
+				event.XProp = map[string][]model.Property{"X-COLOR": {{Name: "X-COLOR:red\r\nX-INJECTED", Value: "red"}}}
+			},
+			expectedErr: encode.ErrInvalidName,
+		},
+		{
+			name: "invalid parameter name",
+			mutate: func(event *model.Event) {
+				event.XProp = map[string][]model.Property{"X-COLOR": {{Name: "X-COLOR", Params: model.Parameters{"X-A=B;X-C": {"d"}}, Value: "red"}}}
+			},
+			expectedErr: encode.ErrInvalidName,
+		},
+		{
+			name: "invalid component name",
+			mutate: func(event *model.Event) {
+				event.OtherComponents = []model.Component{{Name: "X-THING\r\nEND:VEVENT"}}
+			},
+			expectedErr: encode.ErrInvalidName,
+		},
+		{
+			name:        "organizer without a calendar address",
+			mutate:      func(event *model.Event) { event.Organizer = &model.Organizer{CommonName: "Jane"} },
+			expectedErr: encode.ErrMissingValue,
+		},
+		{
+			name:        "attendee without a calendar address",
+			mutate:      func(event *model.Event) { event.Attendees = []model.Attendee{{CommonName: "John"}} },
+			expectedErr: encode.ErrMissingValue,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := validEvent()
+			tc.mutate(&event)
+			calendar := &model.Calendar{Version: "2.0", ProdID: "-//Event//Event Calendar//EN", Events: []model.Event{event}}
+			var output strings.Builder
+			assert.ErrorIs(t, encode.Encode(&output, calendar), tc.expectedErr)
+		})
+	}
+
+	assert.ErrorIs(t, encode.Encode(&strings.Builder{}, nil), encode.ErrNilCalendar)
+
+	var output strings.Builder
+	assert.ErrorIs(t, encode.Encode(&output, &model.Calendar{}), encode.ErrMissingValue)
+	assert.ErrorIs(t, encode.Encode(&output, &model.Calendar{Version: "2.0"}), encode.ErrMissingValue)
+	assert.ErrorIs(t, encode.Encode(&output, &model.Calendar{ProdID: "-//Event//Event Calendar//EN"}), encode.ErrMissingValue)
+	assert.Empty(t, output.String(), "nothing is written for a calendar without VERSION or PRODID")
+}
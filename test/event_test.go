@@ -2,7 +2,9 @@ package test
 
 import (
 	_ "embed"
+	"io"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -50,6 +52,44 @@ var (
 	testEventAlarmMissingAttendeeEmailInput string
 	//go:embed test_data/events/valid_test_event_with_rrule.ical
 	testEventWithRRuleInput string
+	//go:embed test_data/events/test_event_escaped_text.ical
+	testEventEscapedTextInput string
+	//go:embed test_data/events/test_event_parameters.ical
+	testEventParametersInput string
+	//go:embed test_data/events/test_event_all_properties.ical
+	testEventAllPropertiesInput string
+	//go:embed test_data/events/test_event_duplicate_class.ical
+	testIcalDuplicateClassInput string
+	//go:embed test_data/events/test_event_duplicate_status.ical
+	testIcalDuplicateStatusInput string
+	//go:embed test_data/events/test_event_duplicate_organizer.ical
+	testIcalDuplicateOrganizerInput string
+	//go:embed test_data/events/test_event_duplicate_geo.ical
+	testIcalDuplicateGeoInput string
+	//go:embed test_data/events/test_event_invalid_priority.ical
+	testIcalInvalidPriorityInput string
+	//go:embed test_data/events/test_event_priority_out_of_range.ical
+	testIcalPriorityOutOfRangeInput string
+	//go:embed test_data/events/test_event_duplicate_zero_priority.ical
+	testIcalDuplicateZeroPriorityInput string
+	//go:embed test_data/events/test_event_full_attendee.ical
+	testEventFullAttendeeInput string
+	//go:embed test_data/events/test_event_invalid_rsvp.ical
+	testIcalInvalidRSVPInput string
+	//go:embed test_data/events/test_event_alarm_triggers.ical
+	testEventAlarmTriggersInput string
+	//go:embed test_data/events/test_event_alarm_local_trigger.ical
+	testEventAlarmLocalTriggerInput string
+	//go:embed test_data/events/test_event_alarm_invalid_trigger.ical
+	testEventAlarmInvalidTriggerInput string
+	//go:embed test_data/events/test_event_attachments.ical
+	testEventAttachmentsInput string
+	//go:embed test_data/events/test_event_invalid_attachment.ical
+	testIcalInvalidAttachmentInput string
+	//go:embed test_data/events/test_event_localized_text.ical
+	testEventLocalizedTextInput string
+	//go:embed test_data/events/test_event_invalid_altrep.ical
+	testIcalInvalidAltRepInput string
 )
 
 func TestValidEvent(t *testing.T) {
@@ -70,29 +110,29 @@ func TestValidEvent(t *testing.T) {
 					{
 						DTStamp:     time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
 						UID:         "13235@example.com",
-						Start:       time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC),
-						End:         time.Date(2025, time.September, 28, 20, 30, 0, 0, time.UTC),
-						Summary:     "Event Summary",
-						Description: "Event Description",
-						Location:    "555 Fake Street",
+						Start:       model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						End:         model.DateTime{Time: time.Date(2025, time.September, 28, 20, 30, 0, 0, time.UTC)},
+						Summary:     model.Text{Value: "Event Summary"},
+						Description: model.Text{Value: "Event Description"},
+						Location:    model.Text{Value: "555 Fake Street"},
 						Organizer: &model.Organizer{
 							CommonName: "JohnSmith",
 							Directory:  &url.URL{Scheme: "ldap", Host: "example.com:6666", Path: "/o=DC Associates,c=US", RawQuery: "??(cn=John%20Smith)"},
 							CalAddress: &url.URL{Scheme: "mailto", Opaque: "jsmith@example.com"},
 							Language:   "en-us",
 							SentBy:     &url.URL{Scheme: "mailto", Opaque: "mailtojsmith@example.com"},
-							OtherParams: map[string]string{
-								"MISCFIELD":  "TEST",
-								"MISCFIELD2": "TEST2",
+							OtherParams: model.Parameters{
+								"MISCFIELD":  {"TEST"},
+								"MISCFIELD2": {"TEST2"},
 							},
 						},
 						Status:       model.EventStatusConfirmed,
 						Sequence:     1,
-						Comment:      []string{"I Am", "A Comment"},
+						Comment:      []model.Text{{Value: "I Am"}, {Value: "A Comment"}},
 						Categories:   []string{"first", "second", "third"},
-						Geo:          []float64{37.386013, -122.082932},
+						Geo:          &model.Geo{Lat: 37.386013, Lon: -122.082932},
 						Transp:       model.EventTranspOpaque,
-						Contacts:     []string{"Jim Dolittle, ABC Industries, +1-919-555-1234"},
+						Contacts:     []model.Text{{Value: "Jim Dolittle, ABC Industries, +1-919-555-1234"}},
 						LastModified: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
 					},
 				},
@@ -101,8 +141,8 @@ func TestValidEvent(t *testing.T) {
 						TimeZoneID: "America/Detroit",
 						Standard: []model.TimeZoneProperty{
 							{
-								TimeZoneOffsetFrom: "+0000",
-								TimeZoneOffsetTo:   "+0000",
+								TimeZoneOffsetFrom: 0,
+								TimeZoneOffsetTo:   0,
 								DTStart:            time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
 							},
 						},
@@ -120,24 +160,24 @@ func TestValidEvent(t *testing.T) {
 					{
 						UID:         "13235@example.com",
 						DTStamp:     time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
-						Start:       time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC),
-						End:         time.Date(2025, time.September, 28, 20, 30, 0, 0, time.UTC),
-						Summary:     "Event with Alarm",
-						Description: "Event Description",
+						Start:       model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						End:         model.DateTime{Time: time.Date(2025, time.September, 28, 20, 30, 0, 0, time.UTC)},
+						Summary:     model.Text{Value: "Event with Alarm"},
+						Description: model.Text{Value: "Event Description"},
 						Alarms: []model.Alarm{
 							{
 								Action:      model.AlarmActionDisplay,
-								Trigger:     "-PT15M",
-								Description: []string{"Reminder: Event starting in 15 minutes"},
+								Trigger:     model.Trigger{Duration: -15 * time.Minute, Related: model.TriggerRelatedStart},
+								Description: []model.Text{{Value: "Reminder: Event starting in 15 minutes"}},
 								Repeat:      2,
 								Duration:    5 * time.Minute,
 							},
 							{
 								Action:      model.AlarmActionEmail,
-								Trigger:     "-PT1H",
-								Description: []string{"Email reminder for upcoming event"},
-								Summary:     "Event Reminder",
-								Attendees:   []url.URL{{Scheme: "mailto", Opaque: "user@example.com"}},
+								Trigger:     model.Trigger{Duration: -time.Hour, Related: model.TriggerRelatedStart},
+								Description: []model.Text{{Value: "Email reminder for upcoming event"}},
+								Summary:     model.Text{Value: "Event Reminder"},
+								Attendees:   []model.Attendee{{CalAddress: &url.URL{Scheme: "mailto", Opaque: "user@example.com"}}},
 							},
 						},
 					},
@@ -154,15 +194,188 @@ func TestValidEvent(t *testing.T) {
 					{
 						UID:     "13235@example.com",
 						DTStamp: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
-						Start:   time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC),
-						End:     time.Date(2025, time.September, 28, 20, 30, 0, 0, time.UTC),
+						Start:   model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						End:     model.DateTime{Time: time.Date(2025, time.September, 28, 20, 30, 0, 0, time.UTC)},
 						RRule: &rrule.RRule{
 							Frequency: rrule.FrequencyDaily,
 							Interval:  1,
 							Count:     getPointer(10),
 						},
-						Summary:     "Event with reccurrence rule",
-						Description: "Event Description",
+						Summary:     model.Text{Value: "Event with reccurrence rule"},
+						Description: model.Text{Value: "Event Description"},
+					},
+				},
+			},
+		},
+		{
+			name:  "Valid VEVENT with every property",
+			input: testEventAllPropertiesInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Event//Event Calendar//EN",
+				Version: "2.0",
+				Method:  "REQUEST",
+				Events: []model.Event{
+					{
+						UID:          "meeting-1@example.com",
+						DTStamp:      time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+						Created:      time.Date(2024, time.December, 20, 8, 0, 0, 0, time.UTC),
+						Start:        model.DateTime{Time: time.Date(2025, time.January, 6, 15, 0, 0, 0, time.UTC)},
+						End:          model.DateTime{Time: time.Date(2025, time.January, 6, 16, 0, 0, 0, time.UTC)},
+						Summary:      model.Text{Value: "Weekly sync"},
+						Class:        model.ClassPrivate,
+						Priority:     2,
+						URL:          "https://example.com/meetings/1",
+						RecurrenceID: model.DateTime{Time: time.Date(2025, time.January, 6, 15, 0, 0, 0, time.UTC)},
+						RRule: &rrule.RRule{
+							Frequency: rrule.FrequencyWeekly,
+							Interval:  1,
+							Count:     getPointer(4),
+						},
+						ExceptionDates: []model.DateTime{
+							{Time: time.Date(2025, time.January, 13, 15, 0, 0, 0, time.UTC)},
+							{Time: time.Date(2025, time.January, 20, 15, 0, 0, 0, time.UTC)},
+						},
+						Rdate:  []model.DateTime{{Time: time.Date(2025, time.February, 1, 15, 0, 0, 0, time.UTC)}},
+						Attach: []model.Attachment{{URI: &url.URL{Scheme: "https", Host: "example.com", Path: "/agenda.pdf"}}},
+						Attendees: []model.Attendee{
+							{
+								CalAddress:          &url.URL{Scheme: "mailto", Opaque: "jane@example.com"},
+								Role:                model.AttendeeRoleRequired,
+								ParticipationStatus: model.ParticipationStatusNeedsAction,
+								RSVP:                true,
+							},
+							{CalAddress: &url.URL{Scheme: "mailto", Opaque: "john@example.com"}},
+						},
+						RequestStatus: []model.RequestStatus{{Code: []int{2, 0}, Description: "Success"}},
+						Related:       []model.Relation{{UID: "meeting-0@example.com"}},
+						Resources:     []model.Text{{Value: "Projector"}, {Value: "Whiteboard"}},
+						IANAProp: map[string][]model.Property{
+							"RDATE": {{Name: "RDATE", Params: model.Parameters{"VALUE": {"PERIOD"}}, Value: "20250301T150000Z/20250301T170000Z"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Valid VEVENT with multi-valued and caret-encoded parameters",
+			input: testEventParametersInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Event//Event Calendar//EN",
+				Version: "2.0",
+				Events: []model.Event{
+					{
+						UID:     "13235@example.com",
+						DTStamp: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+						Start:   model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						Organizer: &model.Organizer{
+							CommonName: "Smith, John",
+							CalAddress: &url.URL{Scheme: "mailto", Opaque: "jsmith@example.com"},
+							OtherParams: model.Parameters{
+								"X-MEMBER": {"mailto:a@example.com", "mailto:b@example.com", "mailto:c@example.com"},
+							},
+						},
+						XProp: map[string][]model.Property{
+							"X-ADDRESS": {{Name: "X-ADDRESS", Params: model.Parameters{"X-LABEL": {"Main St.\nSpringfield \"Home\""}}, Value: "Home"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Valid VEVENT with relative and absolute alarm triggers",
+			input: testEventAlarmTriggersInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Event//Event Calendar//EN",
+				Version: "2.0",
+				Events: []model.Event{
+					{
+						UID:      "13238@example.com",
+						DTStamp:  time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+						Start:    model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						Duration: 2 * time.Hour,
+						Summary:  model.Text{Value: "Event with Triggers"},
+						Alarms: []model.Alarm{
+							{
+								Action:      model.AlarmActionDisplay,
+								Trigger:     model.Trigger{Related: model.TriggerRelatedEnd},
+								Description: []model.Text{{Value: "Event has ended"}},
+							},
+							{
+								Action:      model.AlarmActionDisplay,
+								Trigger:     model.Trigger{Time: time.Date(2025, time.September, 27, 9, 0, 0, 0, time.UTC)},
+								Description: []model.Text{{Value: "Event is tomorrow"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Valid VEVENT with LANGUAGE and ALTREP on text properties",
+			input: testEventLocalizedTextInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Event//Event Calendar//EN",
+				Version: "2.0",
+				Events: []model.Event{
+					{
+						UID:         "13240@example.com",
+						DTStamp:     time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+						Start:       model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						Summary:     model.Text{Value: "Jahreshauptversammlung", Language: "de"},
+						Description: model.Text{Value: "The annual meeting, with agenda", AltRep: &url.URL{Scheme: "cid", Opaque: "part1.0001@example.org"}},
+						Location:    model.Text{Value: "Konferenzraum 1", Language: "de", AltRep: &url.URL{Scheme: "http", Host: "example.com", Path: "/raum-1.html"}},
+						Comment:     []model.Text{{Value: "Bring a laptop", Language: "en"}},
+						Contacts: []model.Text{{
+							Value:  "Jim Dolittle, ABC Industries",
+							AltRep: &url.URL{Scheme: "ldap", Host: "example.com:6666", Path: "/o=ABC Industries,c=US", RawQuery: "??(cn=Jim%20Dolittle)"},
+						}},
+						Resources: []model.Text{{Value: "Projecteur", Language: "fr"}, {Value: "Tableau blanc", Language: "fr"}},
+					},
+				},
+			},
+		},
+		{
+			name:  "Valid VEVENT with every ATTENDEE parameter",
+			input: testEventFullAttendeeInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Event//Event Calendar//EN",
+				Version: "2.0",
+				Events: []model.Event{
+					{
+						UID:     "13236@example.com",
+						DTStamp: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+						Start:   model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+						Attendees: []model.Attendee{
+							{
+								CalAddress:       &url.URL{Scheme: "mailto", Opaque: "jane@example.com"},
+								CommonName:       "Doe, Jane",
+								CalendarUserType: model.CalendarUserTypeIndividual,
+								Member: []*url.URL{
+									{Scheme: "mailto", Opaque: "dev@example.com"},
+									{Scheme: "mailto", Opaque: "ops@example.com"},
+								},
+								Role:                model.AttendeeRoleChair,
+								ParticipationStatus: model.ParticipationStatusAccepted,
+								DelegatedFrom:       []*url.URL{{Scheme: "mailto", Opaque: "boss@example.com"}},
+								SentBy:              &url.URL{Scheme: "mailto", Opaque: "assistant@example.com"},
+								Directory:           &url.URL{Scheme: "ldap", Host: "example.com", Path: "/cn=Jane"},
+								Language:            "en",
+								OtherParams:         model.Parameters{"X-TEAM": {"platform"}},
+							},
+							{
+								CalAddress:          &url.URL{Scheme: "mailto", Opaque: "room1@example.com"},
+								CalendarUserType:    model.CalendarUserTypeRoom,
+								Role:                model.AttendeeRoleNonParticipant,
+								ParticipationStatus: model.ParticipationStatusDelegated,
+								DelegatedTo:         []*url.URL{{Scheme: "mailto", Opaque: "room2@example.com"}},
+							},
+							{
+								CalAddress:          &url.URL{Scheme: "mailto", Opaque: "bot@example.com"},
+								CalendarUserType:    "X-BOT",
+								ParticipationStatus: model.ParticipationStatusTentative,
+								RSVP:                true,
+							},
+						},
 					},
 				},
 			},
@@ -177,6 +390,49 @@ func TestValidEvent(t *testing.T) {
 	}
 }
 
+func TestEventTextEscapes(t *testing.T) {
+	testCases := []struct {
+		name          string
+		options       []parse.Option
+		expectedEvent model.Event
+	}{
+		{
+			name: "TEXT values are unescaped",
+			expectedEvent: model.Event{
+				UID:         "13235@example.com",
+				DTStamp:     time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Start:       model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+				Summary:     model.Text{Value: "Planning, budget; and review"},
+				Description: model.Text{Value: "Agenda:\n1. Budget\n2. Review\\Wrap-up"},
+				Location:    model.Text{Value: "Room 1, Building A"},
+				Comment:     []model.Text{{Value: "Bring a laptop, charger"}},
+				Categories:  []string{"Meeting", "Smith, John", "Work"},
+			},
+		},
+		{
+			name:    "TEXT values are kept raw",
+			options: []parse.Option{parse.WithRawText()},
+			expectedEvent: model.Event{
+				UID:         "13235@example.com",
+				DTStamp:     time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Start:       model.DateTime{Time: time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)},
+				Summary:     model.Text{Value: `Planning\, budget\; and review`},
+				Description: model.Text{Value: `Agenda:\n1. Budget\n2. Review\\Wrap-up`},
+				Location:    model.Text{Value: `Room 1\, Building A`},
+				Comment:     []model.Text{{Value: `Bring a laptop\, charger`}},
+				Categories:  []string{"Meeting", `Smith\, John`, "Work"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, err := parse.IcalReaderWithOptions(strings.NewReader(testEventEscapedTextInput), tc.options...)
+			assert.NoError(t, err)
+			assert.Equal(t, []model.Event{tc.expectedEvent}, calendar.Events)
+		})
+	}
+}
+
 func TestInvalidEvent(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -238,10 +494,54 @@ func TestInvalidEvent(t *testing.T) {
 			name:  "VALARM EMAIL missing ATTENDEE",
 			input: testEventAlarmMissingAttendeeEmailInput,
 		},
+		{
+			name:  "VALARM absolute TRIGGER not in UTC",
+			input: testEventAlarmLocalTriggerInput,
+		},
+		{
+			name:  "VALARM TRIGGER with unknown RELATED",
+			input: testEventAlarmInvalidTriggerInput,
+		},
+		{
+			name:  "Inline ATTACH that is not valid base64",
+			input: testIcalInvalidAttachmentInput,
+		},
+		{
+			name:  "LOCATION with an invalid ALTREP",
+			input: testIcalInvalidAltRepInput,
+		},
 		{
 			name:  "Invalid RRULE",
 			input: testIcalInvalidRRuleInput,
 		},
+		{
+			name:  "Duplicate CLASS",
+			input: testIcalDuplicateClassInput,
+		},
+		{
+			name:  "Duplicate STATUS",
+			input: testIcalDuplicateStatusInput,
+		},
+		{
+			name:  "Duplicate ORGANIZER",
+			input: testIcalDuplicateOrganizerInput,
+		},
+		{
+			name:  "Invalid PRIORITY",
+			input: testIcalInvalidPriorityInput,
+		},
+		{
+			name:  "PRIORITY above 9",
+			input: testIcalPriorityOutOfRangeInput,
+		},
+		{
+			name:  "Duplicate PRIORITY of 0",
+			input: testIcalDuplicateZeroPriorityInput,
+		},
+		{
+			name:  "Invalid ATTENDEE RSVP",
+			input: testIcalInvalidRSVPInput,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -252,6 +552,94 @@ func TestInvalidEvent(t *testing.T) {
 	}
 }
 
+func TestEventAttachments(t *testing.T) {
+	calendar, err := parse.IcalString(testEventAttachmentsInput)
+	assert.NoError(t, err)
+	if !assert.NotNil(t, calendar) || !assert.Len(t, calendar.Events, 1) {
+		return
+	}
+
+	inline := model.NewInlineAttachment([]byte("Hello, iCalendar!"), "text/plain")
+	inline.OtherParams = model.Parameters{"X-FILENAME": {"hello.txt"}}
+	assert.Equal(t, []model.Attachment{
+		{URI: &url.URL{Scheme: "https", Host: "example.com", Path: "/agenda.pdf"}, FormatType: "application/pdf"},
+		inline,
+	}, calendar.Events[0].Attach)
+
+	uri, document := calendar.Events[0].Attach[0], calendar.Events[0].Attach[1]
+	assert.False(t, uri.IsInline())
+	assert.True(t, document.IsInline())
+	assert.Equal(t, "SGVsbG8sIGlDYWxlbmRhciE=", document.Base64())
+
+	data, err := document.Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("Hello, iCalendar!"), data)
+
+	streamed, err := io.ReadAll(document.Reader())
+	assert.NoError(t, err)
+	assert.Equal(t, data, streamed)
+}
+
+func TestEventTriggerInstant(t *testing.T) {
+	start := time.Date(2025, time.September, 28, 18, 30, 0, 0, time.UTC)
+	before := model.Trigger{Duration: -15 * time.Minute, Related: model.TriggerRelatedStart}
+	atEnd := model.Trigger{Related: model.TriggerRelatedEnd}
+	testCases := []struct {
+		name            string
+		trigger         model.Trigger
+		event           model.Event
+		expectedInstant time.Time
+		expectedOK      bool
+	}{
+		{
+			name:            "Relative to the start",
+			trigger:         before,
+			event:           model.Event{Start: model.DateTime{Time: start}},
+			expectedInstant: start.Add(-15 * time.Minute),
+			expectedOK:      true,
+		},
+		{
+			name:            "Relative to DTEND",
+			trigger:         atEnd,
+			event:           model.Event{Start: model.DateTime{Time: start}, End: model.DateTime{Time: start.Add(time.Hour)}},
+			expectedInstant: start.Add(time.Hour),
+			expectedOK:      true,
+		},
+		{
+			name:            "Relative to the end of a DURATION",
+			trigger:         atEnd,
+			event:           model.Event{Start: model.DateTime{Time: start}, Duration: 2 * time.Hour},
+			expectedInstant: start.Add(2 * time.Hour),
+			expectedOK:      true,
+		},
+		{
+			name:            "Relative to the end of an all-day event",
+			trigger:         atEnd,
+			event:           model.Event{Start: model.DateTime{Time: time.Date(2025, time.September, 28, 0, 0, 0, 0, time.UTC), Kind: model.DateTimeDate}},
+			expectedInstant: time.Date(2025, time.September, 29, 0, 0, 0, 0, time.UTC),
+			expectedOK:      true,
+		},
+		{
+			name:            "Absolute",
+			trigger:         model.Trigger{Time: start.Add(-24 * time.Hour)},
+			event:           model.Event{Start: model.DateTime{Time: start}},
+			expectedInstant: start.Add(-24 * time.Hour),
+			expectedOK:      true,
+		},
+		{
+			name:    "Event without a start",
+			trigger: before,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instant, ok := tc.trigger.EventInstant(&tc.event)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedInstant, instant)
+		})
+	}
+}
+
 // TODO: replace with calls to New once go 1.26 is released
 func getPointer[T any](v T) *T {
 	return &v
@@ -40,15 +40,15 @@ func TestValidFreeBusy(t *testing.T) {
 					{
 						UID:     "freebusy123@example.com",
 						DTStamp: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
-						Contact: "John Doe, Scheduling Assistant, +1-555-0123",
+						Contact: model.Text{Value: "John Doe, Scheduling Assistant, +1-555-0123"},
 						DTStart: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
 						DTEnd:   time.Date(2024, time.January, 31, 23, 59, 59, 0, time.UTC),
 						Organizer: &model.Organizer{
 							CommonName: "Calendar Owner",
 							CalAddress: &url.URL{Scheme: "mailto", Opaque: "owner@example.com"},
 						},
-						Attendees: []url.URL{{Scheme: "mailto", Opaque: "user1@example.com"}, {Scheme: "mailto", Opaque: "user2@example.com"}},
-						Comment:   []string{"Available for meetings during business hours"},
+						Attendees: []model.Attendee{{CalAddress: &url.URL{Scheme: "mailto", Opaque: "user1@example.com"}}, {CalAddress: &url.URL{Scheme: "mailto", Opaque: "user2@example.com"}}},
+						Comment:   []model.Text{{Value: "Available for meetings during business hours"}},
 						FreeBusy: []model.FreeBusyTime{
 							{
 								Start:  time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
@@ -66,7 +66,8 @@ func TestValidFreeBusy(t *testing.T) {
 								Status: model.FreeBusyStatusBusyTentative,
 							},
 						},
-						URL: "https://calendar.example.com/freebusy/123",
+						URL:           "https://calendar.example.com/freebusy/123",
+						RequestStatus: []model.RequestStatus{{Code: []int{2, 0}, Description: "Success"}},
 					},
 				},
 			},
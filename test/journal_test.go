@@ -20,6 +20,8 @@ var (
 	testJournalDuplicateUIDInput string
 	//go:embed test_data/journals/test_journal_multiple_exdates.ical
 	testJournalMultipleExdatesInput string
+	//go:embed test_data/journals/test_journal_with_alarm.ical
+	testJournalWithAlarmInput string
 )
 
 func TestValidJournal(t *testing.T) {
@@ -38,21 +40,21 @@ func TestValidJournal(t *testing.T) {
 					{
 						UID:          "journal123@example.com",
 						DTStamp:      time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
-						Summary:      "Project status update",
-						Description:  []string{"Completed the initial research phase", "Identified key stakeholders and requirements"},
-						Class:        model.JournalClassConfidential,
+						Summary:      model.Text{Value: "Project status update"},
+						Description:  []model.Text{{Value: "Completed the initial research phase"}, {Value: "Identified key stakeholders and requirements"}},
+						Class:        model.ClassConfidential,
 						Status:       model.JournalStatusFinal,
 						Created:      time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
 						LastModified: time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC),
-						DTStart:      time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+						DTStart:      model.DateTime{Time: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)},
 						Organizer: &model.Organizer{
 							CommonName: "Project Lead",
 							CalAddress: &url.URL{Scheme: "mailto", Opaque: "lead@example.com"},
 						},
-						Attendees:  []url.URL{{Scheme: "mailto", Opaque: "stakeholder1@example.com"}, {Scheme: "mailto", Opaque: "stakeholder2@example.com"}},
-						Contacts:   []string{"Jane Doe, Project Manager, +1-555-0456"},
+						Attendees:  []model.Attendee{{CalAddress: &url.URL{Scheme: "mailto", Opaque: "stakeholder1@example.com"}}, {CalAddress: &url.URL{Scheme: "mailto", Opaque: "stakeholder2@example.com"}}},
+						Contacts:   []model.Text{{Value: "Jane Doe, Project Manager, +1-555-0456"}},
 						Categories: []string{"work", "project", "status"},
-						Comment:    []string{"This journal entry documents the completion of Phase 1"},
+						Comment:    []model.Text{{Value: "This journal entry documents the completion of Phase 1"}},
 						URL:        "https://project.example.com/journal/123",
 					},
 				},
@@ -68,15 +70,38 @@ func TestValidJournal(t *testing.T) {
 					{
 						UID:         "journal123@example.com",
 						DTStamp:     time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
-						DTStart:     time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
-						Summary:     "Journal with Multiple Exception Dates",
-						Description: []string{"This journal has multiple exception dates to test the append functionality"},
-						Class:       model.JournalClassConfidential,
+						DTStart:     model.DateTime{Time: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)},
+						Summary:     model.Text{Value: "Journal with Multiple Exception Dates"},
+						Description: []model.Text{{Value: "This journal has multiple exception dates to test the append functionality"}},
+						Class:       model.ClassConfidential,
 						Status:      model.JournalStatusFinal,
-						ExceptionDates: []time.Time{
-							time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC),
-							time.Date(2024, time.January, 22, 9, 0, 0, 0, time.UTC),
-							time.Date(2024, time.January, 29, 9, 0, 0, 0, time.UTC),
+						ExceptionDates: []model.DateTime{
+							{Time: time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC)},
+							{Time: time.Date(2024, time.January, 22, 9, 0, 0, 0, time.UTC)},
+							{Time: time.Date(2024, time.January, 29, 9, 0, 0, 0, time.UTC)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Valid VJOURNAL with VALARM",
+			input: testJournalWithAlarmInput,
+			expectedCalendar: &model.Calendar{
+				ProdID:  "-//Test//Journal Calendar//EN",
+				Version: "2.0",
+				Journals: []model.Journal{
+					{
+						UID:     "journal-alarm@example.com",
+						DTStamp: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+						DTStart: model.DateTime{Time: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)},
+						Summary: model.Text{Value: "Journal with Alarm"},
+						Alarms: []model.Alarm{
+							{
+								Action:      model.AlarmActionDisplay,
+								Trigger:     model.Trigger{Duration: -15 * time.Minute, Related: model.TriggerRelatedStart},
+								Description: []model.Text{{Value: "Review the journal entry"}},
+							},
 						},
 					},
 				},
@@ -0,0 +1,107 @@
+package test
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed test_data/calendar/lenient_calendar.ical
+var testLenientCalendarInput string
+
+func TestLenientParse(t *testing.T) {
+	type diagnostic struct {
+		severity parse.Severity
+		line     int
+		path     string
+		property string
+		err      error
+	}
+	expectedDiagnostics := []diagnostic{
+		{parse.SeverityError, 14, "VCALENDAR/VEVENT[1]", "GEO", parse.ErrInvalidGeoPropertyLatitude},
+		{parse.SeverityError, 16, "VCALENDAR/VEVENT[1]", "SEQUENCE", parse.ErrDuplicatePropertyInComponent},
+		{parse.SeverityWarning, 18, "VCALENDAR", "", parse.ErrInvalidCalendarEmptyLine},
+		{parse.SeverityError, 23, "VCALENDAR/VEVENT[2]", "END", parse.ErrMissingEventUIDProperty},
+		{parse.SeverityError, 24, "VCALENDAR", "BEGIN", parse.ErrInvalidComponentNesting},
+		{parse.SeverityWarning, 27, "VCALENDAR", "END", parse.ErrTemplateInvalidEndBlock},
+		{parse.SeverityWarning, 32, "VCALENDAR/VTODO[0]", "", parse.ErrInvalidCalendarFormatMissingEnd},
+	}
+	expectedCalendar := model.Calendar{
+		Version: "2.0",
+		ProdID:  "-//Test//Lenient//EN",
+		Events: []model.Event{
+			{
+				UID:     "good@example.com",
+				DTStamp: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Start:   model.DateTime{Time: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)},
+				Summary: model.Text{Value: "Good event"},
+			},
+			{
+				UID:      "bad-geo@example.com",
+				DTStamp:  time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Start:    model.DateTime{Time: time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC)},
+				Sequence: 1,
+			},
+		},
+		Todos: []model.Todo{
+			{
+				UID:     "todo@example.com",
+				DTStamp: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				DTStart: model.DateTime{Time: time.Date(2024, time.January, 4, 9, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	calendar, diagnostics := parse.IcalReaderLenient(strings.NewReader(testLenientCalendarInput))
+	if assert.NotNil(t, calendar) {
+		assert.Equal(t, expectedCalendar, *calendar)
+	}
+	if assert.Len(t, diagnostics, len(expectedDiagnostics)) {
+		for i, expected := range expectedDiagnostics {
+			assert.Equal(t, expected.severity, diagnostics[i].Severity, "diagnostic %d", i)
+			assert.Equal(t, expected.line, diagnostics[i].Line, "diagnostic %d", i)
+			assert.Equal(t, expected.path, diagnostics[i].Path, "diagnostic %d", i)
+			assert.Equal(t, expected.property, diagnostics[i].Property, "diagnostic %d", i)
+			assert.ErrorIs(t, diagnostics[i], expected.err, "diagnostic %d", i)
+		}
+	}
+
+	// The same input is rejected outright by the default parser.
+	calendar, err := parse.IcalString(testLenientCalendarInput)
+	assert.Nil(t, calendar)
+	assert.ErrorIs(t, err, parse.ErrInvalidGeoPropertyLatitude)
+}
+
+func TestLenientParseFatal(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedError error
+	}{
+		{
+			name:          "Empty input",
+			input:         "",
+			expectedError: parse.ErrNoCalendarFound,
+		},
+		{
+			name:          "Calendar with no BEGIN:VCALENDAR",
+			input:         testInvalidBeginCalendarInput,
+			expectedError: parse.ErrInvalidCalendarFormatMissingBegin,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, diagnostics := parse.IcalReaderLenient(strings.NewReader(tc.input))
+			assert.Nil(t, calendar)
+			if assert.Len(t, diagnostics, 1) {
+				assert.Equal(t, parse.SeverityFatal, diagnostics[0].Severity)
+				assert.ErrorIs(t, diagnostics[0], tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimits(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		limits        parse.Limits
+		expectedError error
+	}{
+		{
+			name:  "Within every limit",
+			input: testIcalWithEventAndTimezoneInput,
+			limits: parse.Limits{
+				MaxLineLength:             100,
+				MaxBytes:                  int64(len(testIcalWithEventAndTimezoneInput)),
+				MaxComponents:             3,
+				MaxPropertiesPerComponent: 18,
+				MaxParameterValues:        1,
+			},
+		},
+		{
+			name:          "MaxLineLength exceeded",
+			input:         testIcalWithEventAndTimezoneInput,
+			limits:        parse.Limits{MaxLineLength: 20},
+			expectedError: parse.ErrLimitExceeded,
+		},
+		{
+			name:          "MaxBytes exceeded",
+			input:         testIcalWithEventAndTimezoneInput,
+			limits:        parse.Limits{MaxBytes: int64(len(testIcalWithEventAndTimezoneInput)) - 1},
+			expectedError: parse.ErrLimitExceeded,
+		},
+		{
+			name:          "MaxComponents exceeded",
+			input:         testIcalWithEventAndTimezoneInput,
+			limits:        parse.Limits{MaxComponents: 2},
+			expectedError: parse.ErrLimitExceeded,
+		},
+		{
+			name:          "MaxPropertiesPerComponent exceeded",
+			input:         testIcalWithEventAndTimezoneInput,
+			limits:        parse.Limits{MaxPropertiesPerComponent: 17},
+			expectedError: parse.ErrLimitExceeded,
+		},
+		{
+			name:          "MaxParameterValues exceeded",
+			input:         testIcalFullOrganizerInput,
+			limits:        parse.Limits{MaxParameterValues: 5},
+			expectedError: parse.ErrLimitExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, err := parse.IcalReaderWithOptions(strings.NewReader(tc.input), parse.WithLimits(tc.limits))
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+				assert.NotNil(t, calendar)
+				return
+			}
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, calendar)
+		})
+	}
+}
+
+func TestParseLimitsLenient(t *testing.T) {
+	// Limits stop a lenient parse too, keeping what was read before.
+	calendar, diagnostics := parse.IcalReaderLenient(strings.NewReader(testIcalWithEventAndTimezoneInput), parse.WithLimits(parse.Limits{MaxComponents: 2}))
+	if assert.NotNil(t, calendar) {
+		assert.Len(t, calendar.TimeZones, 1)
+		assert.Empty(t, calendar.Events)
+	}
+	if assert.NotEmpty(t, diagnostics) {
+		assert.Equal(t, parse.SeverityFatal, diagnostics[0].Severity)
+		assert.ErrorIs(t, diagnostics[0], parse.ErrLimitExceeded)
+	}
+}
+
+func TestParseLineLongerThan64KB(t *testing.T) {
+	// Inline attachments easily exceed the 64KB token limit of bufio.Scanner.
+	attachment := strings.Repeat("QUJD", 40000)
+	input := strings.Replace(testTodoInput, "BEGIN:VTODO", "BEGIN:VTODO\nATTACH;ENCODING=BASE64;VALUE=BINARY:"+attachment, 1)
+
+	calendar, err := parse.IcalString(input)
+	assert.NoError(t, err)
+	if assert.NotNil(t, calendar) && assert.Len(t, calendar.Todos, 1) {
+		assert.Equal(t, []model.Attachment{model.NewBase64Attachment(attachment, "")}, calendar.Todos[0].Attach)
+	}
+}
@@ -0,0 +1,140 @@
+package test
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/michael-gallo/simpleical/model"
+	"github.com/michael-gallo/simpleical/parse"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	//go:embed test_data/calendar/calendar_producer_bugs.ical
+	testProducerBugsCalendarInput string
+	//go:embed test_data/events/test_event_missing_dtstamp.ical
+	testEventMissingDTStampInput string
+	//go:embed test_data/events/test_event_unknown_status.ical
+	testEventUnknownStatusInput string
+	//go:embed test_data/events/test_event_trailing_spaces.ical
+	testEventTrailingSpacesInput string
+)
+
+func TestParseOptionsSuccess(t *testing.T) {
+	testCases := []struct {
+		name             string
+		input            string
+		options          []parse.Option
+		expectedCalendar *model.Calendar
+	}{
+		{
+			name:    "Producer bugs accepted by lenient switches",
+			input:   testProducerBugsCalendarInput,
+			options: []parse.Option{parse.WithSkipByteOrderMark(), parse.WithAllowBlankLines(), parse.WithAllowMissingEnd()},
+			expectedCalendar: &model.Calendar{
+				Version: "2.0",
+				ProdID:  "-//Example//Producer Bugs//EN",
+				Events: []model.Event{
+					{
+						UID:     "producer-bugs@example.com",
+						Start:   model.DateTime{Time: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)},
+						Summary: model.Text{Value: "Truncated feed"},
+					},
+				},
+			},
+		},
+		{
+			name:    "Trailing spaces kept",
+			input:   testEventTrailingSpacesInput,
+			options: []parse.Option{parse.WithKeepTrailingSpaces()},
+			expectedCalendar: &model.Calendar{
+				Version: "2.0",
+				ProdID:  "-//Event//Event Calendar//EN",
+				Events: []model.Event{
+					{
+						UID:     "trailing-spaces@example.com",
+						DTStamp: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+						Start:   model.DateTime{Time: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)},
+						Summary: model.Text{Value: "Padded   "},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, err := parse.IcalReaderWithOptions(strings.NewReader(tc.input), tc.options...)
+			assert.NoError(t, err)
+			assert.Equal(t, *tc.expectedCalendar, *calendar)
+		})
+	}
+}
+
+func TestParseOptionsError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		options       []parse.Option
+		expectedError error
+	}{
+		{
+			name:          "Producer bugs rejected by default",
+			input:         testProducerBugsCalendarInput,
+			expectedError: parse.ErrInvalidCalendarFormatMissingBegin,
+		},
+		{
+			name:          "Blank line rejected without WithAllowBlankLines",
+			input:         testProducerBugsCalendarInput,
+			options:       []parse.Option{parse.WithSkipByteOrderMark()},
+			expectedError: parse.ErrInvalidCalendarEmptyLine,
+		},
+		{
+			name:          "Missing END rejected without WithAllowMissingEnd",
+			input:         testProducerBugsCalendarInput,
+			options:       []parse.Option{parse.WithSkipByteOrderMark(), parse.WithAllowBlankLines()},
+			expectedError: parse.ErrInvalidCalendarFormatMissingEnd,
+		},
+		{
+			name:          "Missing DTSTAMP with WithRequireDTStamp",
+			input:         testEventMissingDTStampInput,
+			options:       []parse.Option{parse.WithRequireDTStamp()},
+			expectedError: parse.ErrMissingEventDTStampProperty,
+		},
+		{
+			name:          "Missing DTSTAMP with WithStrict",
+			input:         testEventMissingDTStampInput,
+			options:       []parse.Option{parse.WithStrict()},
+			expectedError: parse.ErrMissingEventDTStampProperty,
+		},
+		{
+			name:          "Unknown STATUS with WithStrictEnums",
+			input:         testEventUnknownStatusInput,
+			options:       []parse.Option{parse.WithStrictEnums()},
+			expectedError: parse.ErrInvalidPropertyValue,
+		},
+		{
+			name:          "IANA property with WithRejectUnknownProperties",
+			input:         testExtraPropertiesCalendarInput,
+			options:       []parse.Option{parse.WithRejectUnknownProperties()},
+			expectedError: parse.ErrInvalidCalendarProperty,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar, err := parse.IcalReaderWithOptions(strings.NewReader(tc.input), tc.options...)
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, calendar)
+		})
+	}
+}
+
+func TestParseOptionsDefaults(t *testing.T) {
+	// Inputs rejected by the options above parse without them.
+	for _, input := range []string{testEventMissingDTStampInput, testEventUnknownStatusInput, testExtraPropertiesCalendarInput} {
+		calendar, err := parse.IcalString(input)
+		assert.NoError(t, err)
+		assert.NotNil(t, calendar)
+	}
+}
@@ -2,12 +2,15 @@ package test
 
 import (
 	_ "embed"
+	"errors"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/michael-gallo/simpleical/model"
 	"github.com/michael-gallo/simpleical/parse"
+	"github.com/michael-gallo/simpleical/rrule"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,6 +25,18 @@ var (
 	testTimezoneDuplicateTZIDInput string
 	//go:embed test_data/timezones/test_timezone_invalid_dtstart.ical
 	testTimezoneInvalidDTStartInput string
+	//go:embed test_data/timezones/test_timezone_dates_and_tzids.ical
+	testTimezoneDatesAndTZIDsInput string
+	//go:embed test_data/timezones/test_timezone_unknown_tzid.ical
+	testTimezoneUnknownTZIDInput string
+	//go:embed test_data/timezones/test_timezone_invalid_date.ical
+	testTimezoneInvalidDateInput string
+	//go:embed test_data/timezones/test_timezone_invalid_offset.ical
+	testTimezoneInvalidOffsetInput string
+	//go:embed test_data/timezones/test_timezone_duplicate_offset.ical
+	testTimezoneDuplicateOffsetInput string
+	//go:embed test_data/timezones/test_timezone_missing_offset.ical
+	testTimezoneMissingOffsetInput string
 )
 
 func TestValidTimezone(t *testing.T) {
@@ -43,21 +58,21 @@ func TestValidTimezone(t *testing.T) {
 						TimeZoneURL: &url.URL{Scheme: "http", Host: "tzurl.org", Path: "/zoneinfo-outlook/America/New_York"},
 						Standard: []model.TimeZoneProperty{
 							{
-								TimeZoneOffsetFrom: "-0400",
-								TimeZoneOffsetTo:   "-0500",
+								TimeZoneOffsetFrom: -4 * 60 * 60,
+								TimeZoneOffsetTo:   -5 * 60 * 60,
 								DTStart:            time.Date(2024, time.January, 1, 2, 0, 0, 0, time.UTC),
 								TimeZoneName:       []string{"EST"},
-								Comment:            []string{"Eastern Standard Time"},
+								Comment:            []model.Text{{Value: "Eastern Standard Time"}},
 								Rdate:              []time.Time{time.Date(2024, time.January, 1, 2, 0, 0, 0, time.UTC)},
 							},
 						},
 						Daylight: []model.TimeZoneProperty{
 							{
-								TimeZoneOffsetFrom: "-0500",
-								TimeZoneOffsetTo:   "-0400",
+								TimeZoneOffsetFrom: -5 * 60 * 60,
+								TimeZoneOffsetTo:   -4 * 60 * 60,
 								DTStart:            time.Date(2024, time.March, 1, 2, 0, 0, 0, time.UTC),
 								TimeZoneName:       []string{"EDT"},
-								Comment:            []string{"Eastern Daylight Time"},
+								Comment:            []model.Text{{Value: "Eastern Daylight Time"}},
 								Rdate:              []time.Time{time.Date(2024, time.March, 1, 2, 0, 0, 0, time.UTC)},
 							},
 						},
@@ -77,27 +92,244 @@ func TestValidTimezone(t *testing.T) {
 
 func TestInvalidTimezone(t *testing.T) {
 	testCases := []struct {
-		name  string
-		input string
+		name          string
+		input         string
+		expectedError error
 	}{
 		{
-			name:  "VTIMEZONE missing TZID",
-			input: testTimezoneMissingTZIDInput,
+			name:          "VTIMEZONE missing TZID",
+			input:         testTimezoneMissingTZIDInput,
+			expectedError: parse.ErrMissingTimezoneTZIDProperty,
 		},
 		{
-			name:  "VTIMEZONE invalid DTSTART",
-			input: testTimezoneInvalidDTStartInput,
+			name:          "VTIMEZONE invalid DTSTART",
+			input:         testTimezoneInvalidDTStartInput,
+			expectedError: parse.ErrParseErrorInComponent,
 		},
 		{
-			name:  "VTIMEZONE duplicate TZID",
-			input: testTimezoneDuplicateTZIDInput,
+			name:          "VTIMEZONE duplicate TZID",
+			input:         testTimezoneDuplicateTZIDInput,
+			expectedError: parse.ErrDuplicatePropertyInComponent,
+		},
+		{
+			name:          "VTIMEZONE invalid TZOFFSETTO",
+			input:         testTimezoneInvalidOffsetInput,
+			expectedError: parse.ErrInvalidPropertyValue,
+		},
+		{
+			name:          "STANDARD duplicate TZOFFSETTO",
+			input:         testTimezoneDuplicateOffsetInput,
+			expectedError: parse.ErrDuplicatePropertyInComponent,
+		},
+		{
+			name:          "DAYLIGHT missing TZOFFSETFROM",
+			input:         testTimezoneMissingOffsetInput,
+			expectedError: parse.ErrMissingTimezoneOffsetFromProperty,
 		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			calendar, err := parse.IcalString(tc.input)
-			assert.Error(t, err)
+			assert.ErrorIs(t, err, tc.expectedError)
 			assert.Nil(t, calendar)
 		})
 	}
 }
+
+func TestParseDateTimes(t *testing.T) {
+	detroit, err := time.LoadLocation("America/Detroit")
+	if !assert.NoError(t, err) {
+		return
+	}
+	eastern := func(offsetHours int) *time.Location {
+		return time.FixedZone("Eastern Standard Time", offsetHours*60*60)
+	}
+	yearly := func(ordinal int, month int) *rrule.RRule {
+		return &rrule.RRule{
+			Frequency: rrule.FrequencyYearly,
+			Interval:  1,
+			Weekday:   []rrule.ByDay{{Weekday: rrule.WeekdaySunday, Interval: ordinal, HasOrdinal: ordinal == 1}},
+			Month:     []int{month},
+		}
+	}
+	dtStamp := time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	allDayUntil := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	calendar, err := parse.IcalString(testTimezoneDatesAndTZIDsInput)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Calendar{
+		ProdID:  "-//Example//Time Zones//EN",
+		Version: "2.0",
+		Events: []model.Event{
+			{
+				UID:     "all-day@example.com",
+				DTStamp: dtStamp,
+				Start:   model.DateTime{Time: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), Kind: model.DateTimeDate},
+				End:     model.DateTime{Time: time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC), Kind: model.DateTimeDate},
+				RRule: &rrule.RRule{
+					Frequency: rrule.FrequencyYearly,
+					Interval:  1,
+					Until:     &allDayUntil,
+					UntilKind: rrule.UntilDate,
+				},
+			},
+			{
+				UID:     "iana@example.com",
+				DTStamp: dtStamp,
+				Start:   model.DateTime{Time: time.Date(2025, time.January, 1, 9, 0, 0, 0, detroit), Kind: model.DateTimeLocal, TZID: "America/Detroit"},
+			},
+			{
+				// The VTIMEZONE follows the event, and daylight saving time applies in July.
+				UID:     "vtimezone@example.com",
+				DTStamp: dtStamp,
+				Start:   model.DateTime{Time: time.Date(2025, time.July, 1, 9, 0, 0, 0, eastern(-4)), Kind: model.DateTimeLocal, TZID: "Eastern Standard Time"},
+				End:     model.DateTime{Time: time.Date(2025, time.July, 1, 10, 0, 0, 0, eastern(-4)), Kind: model.DateTimeLocal, TZID: "Eastern Standard Time"},
+			},
+		},
+		Todos: []model.Todo{
+			{
+				UID:     "floating@example.com",
+				DTStamp: dtStamp,
+				DTStart: model.DateTime{Time: time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC), Kind: model.DateTimeFloating},
+				Due:     model.DateTime{Time: time.Date(2025, time.January, 15, 17, 0, 0, 0, eastern(-5)), Kind: model.DateTimeLocal, TZID: "Eastern Standard Time"},
+				ExceptionDates: []model.DateTime{
+					{Time: time.Date(2025, time.January, 8, 0, 0, 0, 0, time.UTC), Kind: model.DateTimeDate},
+					{Time: time.Date(2025, time.January, 9, 0, 0, 0, 0, time.UTC), Kind: model.DateTimeDate},
+				},
+			},
+		},
+		TimeZones: []model.TimeZone{
+			{
+				TimeZoneID: "Eastern Standard Time",
+				Standard: []model.TimeZoneProperty{
+					{
+						TimeZoneOffsetFrom: -4 * 60 * 60,
+						TimeZoneOffsetTo:   -5 * 60 * 60,
+						DTStart:            time.Date(1601, time.January, 1, 2, 0, 0, 0, time.UTC),
+						RRule:              yearly(1, 11),
+						Rdate: []time.Time{
+							time.Date(1971, time.January, 1, 0, 0, 0, 0, time.UTC),
+							time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC),
+						},
+					},
+				},
+				Daylight: []model.TimeZoneProperty{
+					{
+						TimeZoneOffsetFrom: -5 * 60 * 60,
+						TimeZoneOffsetTo:   -4 * 60 * 60,
+						DTStart:            time.Date(1601, time.January, 1, 2, 0, 0, 0, time.UTC),
+						RRule:              yearly(2, 3),
+					},
+				},
+			},
+		},
+	}, *calendar)
+}
+
+func TestParseUnknownTimeZone(t *testing.T) {
+	t.Run("Strict parse keeps the wall clock time", func(t *testing.T) {
+		calendar, err := parse.IcalString(testTimezoneUnknownTZIDInput)
+		assert.NoError(t, err)
+		if assert.NotNil(t, calendar) && assert.Len(t, calendar.Events, 1) {
+			assert.True(t, calendar.Events[0].Start.Unresolved)
+			assert.Equal(t, time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC), calendar.Events[0].Start.Time)
+		}
+	})
+
+	t.Run("Strict parse fails when time zones are required", func(t *testing.T) {
+		calendar, err := parse.IcalReaderWithOptions(strings.NewReader(testTimezoneUnknownTZIDInput), parse.WithRequireKnownTimeZones())
+		assert.ErrorIs(t, err, parse.ErrUnknownTimeZone)
+		assert.Nil(t, calendar)
+
+		// The error points at the property that used the TZID, not at the end of the calendar.
+		var parseError *parse.ParseError
+		if assert.ErrorAs(t, err, &parseError) {
+			assert.Equal(t, 7, parseError.Line)
+			assert.Equal(t, "DTSTART", parseError.Property)
+			assert.Equal(t, "VCALENDAR/VEVENT[0]", parseError.Path)
+		}
+	})
+
+	t.Run("Lenient parse keeps the wall clock time", func(t *testing.T) {
+		calendar, diagnostics := parse.IcalReaderLenient(strings.NewReader(testTimezoneUnknownTZIDInput))
+		if assert.Len(t, diagnostics, 1) {
+			assert.ErrorIs(t, diagnostics[0], parse.ErrUnknownTimeZone)
+			assert.Equal(t, parse.SeverityWarning, diagnostics[0].Severity)
+		}
+		if assert.NotNil(t, calendar) && assert.Len(t, calendar.Events, 1) {
+			assert.Equal(t, model.DateTime{
+				Time:       time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC),
+				Kind:       model.DateTimeLocal,
+				TZID:       "Nowhere/Special",
+				Unresolved: true,
+			}, calendar.Events[0].Start)
+		}
+	})
+
+	t.Run("Decoder keeps the wall clock time of a time zone defined later", func(t *testing.T) {
+		decoder := parse.NewDecoder(strings.NewReader(testTimezoneDatesAndTZIDsInput))
+		var starts []model.DateTime
+		for component, err := range decoder.All() {
+			if !assert.NoError(t, err) {
+				return
+			}
+			if event, ok := component.(*model.Event); ok {
+				starts = append(starts, event.Start)
+			}
+		}
+		if assert.Len(t, starts, 3) {
+			assert.False(t, starts[1].Unresolved)
+			assert.True(t, starts[2].Unresolved)
+		}
+	})
+
+	t.Run("Decoder needs the time zone before it is used", func(t *testing.T) {
+		decoder := parse.NewDecoder(strings.NewReader(testTimezoneDatesAndTZIDsInput), parse.WithRequireKnownTimeZones())
+		var err error
+		for _, err = range decoder.All() {
+			if err != nil {
+				break
+			}
+		}
+		assert.ErrorIs(t, err, parse.ErrUnknownTimeZone)
+		var parseError *parse.ParseError
+		if assert.True(t, errors.As(err, &parseError)) {
+			assert.Equal(t, 19, parseError.Line)
+		}
+	})
+}
+
+func TestParseInvalidDate(t *testing.T) {
+	calendar, err := parse.IcalString(testTimezoneInvalidDateInput)
+	assert.ErrorIs(t, err, parse.ErrParseErrorInComponent)
+	assert.Nil(t, calendar)
+}
+
+func TestParseUTCOffset(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expected      model.UTCOffset
+		expectedError error
+	}{
+		{name: "Negative offset", value: "-0500", expected: -5 * 60 * 60},
+		{name: "Positive offset with minutes", value: "+0530", expected: 5*60*60 + 30*60},
+		{name: "Offset with seconds", value: "+013045", expected: 60*60 + 30*60 + 45},
+		{name: "Zero offset", value: "+0000", expected: 0},
+		{name: "Negative zero offset", value: "-0000", expectedError: model.ErrInvalidUTCOffset},
+		{name: "Missing sign", value: "0500", expectedError: model.ErrInvalidUTCOffset},
+		{name: "Too short", value: "+05", expectedError: model.ErrInvalidUTCOffset},
+		{name: "Not a number", value: "+05AB", expectedError: model.ErrInvalidUTCOffset},
+		{name: "Minutes out of range", value: "+0560", expectedError: model.ErrInvalidUTCOffset},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			offset, err := model.ParseUTCOffset(testCase.value)
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.Equal(t, testCase.expected, offset)
+			if testCase.expectedError == nil {
+				assert.Equal(t, testCase.value, offset.String())
+			}
+		})
+	}
+}
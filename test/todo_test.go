@@ -21,8 +21,16 @@ var (
 	testTodoBothDueAndDurationInput string
 	//go:embed test_data/todos/test_todo_duplicate_uid.ical
 	testTodoDuplicateUIDInput string
+	//go:embed test_data/todos/test_todo_duplicate_status.ical
+	testTodoDuplicateStatusInput string
+	//go:embed test_data/todos/test_todo_duplicate_organizer.ical
+	testTodoDuplicateOrganizerInput string
 	//go:embed test_data/todos/test_todo_invalid_geo.ical
 	testTodoInvalidGeoInput string
+	//go:embed test_data/todos/test_todo_hierarchy.ical
+	testTodoHierarchyInput string
+	//go:embed test_data/todos/test_todo_geo_out_of_range.ical
+	testTodoGeoOutOfRangeInput string
 )
 
 func TestValidTodo(t *testing.T) {
@@ -41,28 +49,32 @@ func TestValidTodo(t *testing.T) {
 					{
 						UID:             "todo123@example.com",
 						DTStamp:         time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
-						Summary:         "Complete project documentation",
-						Description:     []string{"Write comprehensive documentation for the new API", "Include examples and usage patterns"},
-						Location:        "Office",
-						Class:           model.TodoClassConfidential,
+						Summary:         model.Text{Value: "Complete project documentation"},
+						Description:     []model.Text{{Value: "Write comprehensive documentation for the new API"}, {Value: "Include examples and usage patterns"}},
+						Location:        model.Text{Value: "Office"},
+						Class:           model.ClassConfidential,
 						Status:          model.TodoStatusInProcess,
 						Priority:        1,
 						PercentComplete: 75,
 						Created:         time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
 						LastModified:    time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC),
-						DTStart:         time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
-						Due:             time.Date(2024, time.January, 30, 17, 0, 0, 0, time.UTC),
+						DTStart:         model.DateTime{Time: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)},
+						Due:             model.DateTime{Time: time.Date(2024, time.January, 30, 17, 0, 0, 0, time.UTC)},
 						Organizer: &model.Organizer{
 							CommonName: "Project Manager",
 							CalAddress: &url.URL{Scheme: "mailto", Opaque: "pm@example.com"},
 						},
-						Attendees:  []url.URL{{Scheme: "mailto", Opaque: "dev1@example.com"}, {Scheme: "mailto", Opaque: "dev2@example.com"}},
-						Contacts:   []string{"John Doe, Engineering Team, +1-555-0123"},
+						Attendees:  []model.Attendee{{CalAddress: &url.URL{Scheme: "mailto", Opaque: "dev1@example.com"}}, {CalAddress: &url.URL{Scheme: "mailto", Opaque: "dev2@example.com"}}},
+						Contacts:   []model.Text{{Value: "John Doe, Engineering Team, +1-555-0123"}},
 						Categories: []string{"work", "urgent", "project"},
-						Comment:    []string{"This is a critical task for the Q1 release"},
-						Resources:  []string{"laptop", "meeting-room"},
-						Geo:        []float64{37.7749, -122.4194},
+						Comment:    []model.Text{{Value: "This is a critical task for the Q1 release"}},
+						Resources:  []model.Text{{Value: "laptop"}, {Value: "meeting-room"}},
+						Geo:        &model.Geo{Lat: 37.7749, Lon: -122.4194},
 						URL:        "https://project.example.com/todo/123",
+						RequestStatus: []model.RequestStatus{
+							{Code: []int{3, 7}, Description: "Invalid calendar user", ExtraData: "mailto:jsmith@example.com", Language: "en"},
+							{Code: []int{2, 8}, Description: "Success, repeating event ignored. Scheduled as a single event.", ExtraData: "RRULE:FREQ=WEEKLY;INTERVAL=2"},
+						},
 					},
 				},
 			},
@@ -94,10 +106,22 @@ func TestInvalidTodo(t *testing.T) {
 			name:  "VTODO invalid GEO",
 			input: testTodoInvalidGeoInput,
 		},
+		{
+			name:  "VTODO GEO latitude out of range",
+			input: testTodoGeoOutOfRangeInput,
+		},
 		{
 			name:  "VTODO duplicate UID",
 			input: testTodoDuplicateUIDInput,
 		},
+		{
+			name:  "VTODO duplicate STATUS",
+			input: testTodoDuplicateStatusInput,
+		},
+		{
+			name:  "VTODO duplicate ORGANIZER",
+			input: testTodoDuplicateOrganizerInput,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -107,3 +131,113 @@ func TestInvalidTodo(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoTriggerInstant(t *testing.T) {
+	start := time.Date(2025, time.September, 28, 9, 0, 0, 0, time.UTC)
+	due := model.Trigger{Duration: -time.Hour, Related: model.TriggerRelatedEnd}
+	testCases := []struct {
+		name            string
+		todo            model.Todo
+		expectedInstant time.Time
+		expectedOK      bool
+	}{
+		{
+			name:            "Relative to DUE",
+			todo:            model.Todo{Due: model.DateTime{Time: start.Add(8 * time.Hour)}},
+			expectedInstant: start.Add(7 * time.Hour),
+			expectedOK:      true,
+		},
+		{
+			name:            "Relative to the end of a DURATION",
+			todo:            model.Todo{DTStart: model.DateTime{Time: start}, Duration: 4 * time.Hour},
+			expectedInstant: start.Add(3 * time.Hour),
+			expectedOK:      true,
+		},
+		{
+			name: "To-do without an end",
+			todo: model.Todo{DTStart: model.DateTime{Time: start}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instant, ok := due.TodoInstant(&tc.todo)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedInstant, instant)
+		})
+	}
+}
+
+func TestRequestStatusClassification(t *testing.T) {
+	testCases := []struct {
+		name             string
+		code             []int
+		expectedSuccess  bool
+		expectedFallback bool
+		expectedFailure  bool
+	}{
+		{name: "Preliminary success", code: []int{1, 0}},
+		{name: "Success", code: []int{2, 0}, expectedSuccess: true},
+		{name: "Success with a three level code", code: []int{2, 0, 0}, expectedSuccess: true},
+		{name: "Fallback", code: []int{2, 8}, expectedFallback: true},
+		{name: "Client error", code: []int{3, 7}, expectedFailure: true},
+		{name: "Scheduling error", code: []int{4, 1}, expectedFailure: true},
+		{name: "No code"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := model.RequestStatus{Code: tc.code}
+			assert.Equal(t, tc.expectedSuccess, status.IsSuccess())
+			assert.Equal(t, tc.expectedFallback, status.IsFallback())
+			assert.Equal(t, tc.expectedFailure, status.IsFailure())
+		})
+	}
+}
+
+func TestTodoHierarchy(t *testing.T) {
+	calendar, err := parse.IcalString(testTodoHierarchyInput)
+	assert.NoError(t, err)
+	if !assert.NotNil(t, calendar) || !assert.Len(t, calendar.Todos, 4) {
+		return
+	}
+	assert.Equal(t, []model.Relation{
+		{UID: "project@example.com", Type: model.RelationTypeParent},
+		{UID: "build@example.com", Type: model.RelationTypeSibling},
+		{UID: "build@example.com", Type: model.RelationTypeFinishToStart, OtherParams: model.Parameters{"GAP": {"PT1H"}}},
+	}, calendar.Todos[2].Related)
+
+	hierarchy := calendar.BuildHierarchy()
+	assert.Equal(t, map[string][]string{
+		"build@example.com": {"project@example.com"},
+		"docs@example.com":  {"project@example.com"},
+		"test@example.com":  {"project@example.com"},
+	}, hierarchy.Parents)
+	assert.Equal(t, map[string][]string{
+		"project@example.com": {"build@example.com", "docs@example.com", "test@example.com"},
+	}, hierarchy.Children)
+	assert.Equal(t, map[string][]string{
+		"build@example.com": {"test@example.com"},
+		"test@example.com":  {"build@example.com"},
+	}, hierarchy.Siblings)
+	assert.Equal(t, map[string][]string{
+		"build@example.com": {"test@example.com"},
+		"test@example.com":  {"build@example.com"},
+	}, hierarchy.DependsOn)
+	assert.Equal(t, []model.DanglingRelation{
+		{UID: "docs@example.com", Relation: model.Relation{UID: "legal@example.com", Type: "X-BLOCKED-BY"}},
+	}, hierarchy.Dangling)
+	assert.Equal(t, [][]string{{"build@example.com", "test@example.com"}}, hierarchy.Cycles)
+}
+
+func TestTodoHierarchyParentCycle(t *testing.T) {
+	calendar := model.Calendar{
+		Todos: []model.Todo{
+			{UID: "a", Related: []model.Relation{{UID: "b"}}},
+			{UID: "b", Related: []model.Relation{{UID: "c"}}},
+			{UID: "c", Related: []model.Relation{{UID: "a"}}},
+			{UID: "d", Related: []model.Relation{{UID: "d", Type: model.RelationTypeChild}}},
+		},
+	}
+	hierarchy := calendar.BuildHierarchy()
+	assert.Empty(t, hierarchy.Dangling)
+	assert.Equal(t, [][]string{{"a", "b", "c"}, {"d"}}, hierarchy.Cycles)
+}